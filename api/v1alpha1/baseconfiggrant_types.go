@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BaseConfigGrantKind is the kind of the BaseConfigGrant CRD
+const BaseConfigGrantKind = "BaseConfigGrant"
+
+// BaseConfigGrantFrom identifies the namespace of the ModelServices
+// that are granted access
+type BaseConfigGrantFrom struct {
+	// Namespace of the ModelServices; "*" matches every namespace
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// BaseConfigGrantTo identifies the base configs that can be referenced
+type BaseConfigGrantTo struct {
+	// Kind of the base config: ConfigMap or ModelServiceBaseConfig
+	// +kubebuilder:validation:Enum=ConfigMap;ModelServiceBaseConfig
+	Kind string `json:"kind"`
+
+	// Name of the base config. If empty, every base config of Kind
+	// in the namespace of the grant can be referenced
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// BaseConfigGrantSpec defines which ModelServices may use the namespace of the grant
+type BaseConfigGrantSpec struct {
+	// From lists the namespaces of the ModelServices that are granted access
+	// +kubebuilder:validation:MinItems=1
+	From []BaseConfigGrantFrom `json:"from"`
+
+	// To lists the base configs in the namespace of the grant that the
+	// ModelServices may reference, directly or as a parent
+	// +optional
+	To []BaseConfigGrantTo `json:"to,omitempty"`
+
+	// AllowChildConfigMaps allows the ModelServices to create the ConfigMaps
+	// listed in their base config in the namespace of the grant.
+	// By default, child ConfigMaps are only created in the namespace of the ModelService
+	// +optional
+	AllowChildConfigMaps bool `json:"allowChildConfigMaps,omitempty"`
+}
+
+// BaseConfigGrant allows ModelServices in other namespaces to reference base
+// configs in its namespace, in the manner of a Gateway API ReferenceGrant.
+// It must be created in the namespace holding the base configs.
+//
+// +kubebuilder:resource:shortName=bcg
+// +kubebuilder:object:root=true
+type BaseConfigGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BaseConfigGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BaseConfigGrantList contains a list of BaseConfigGrant.
+type BaseConfigGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BaseConfigGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BaseConfigGrant{}, &BaseConfigGrantList{})
+}
@@ -0,0 +1,35 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterModelServiceBaseConfig is the Schema for the clustermodelservicebaseconfigs API.
+// It is the cluster-scoped variant of ModelServiceBaseConfig, so that
+// a single platform base config can be used from every namespace.
+//
+// +kubebuilder:resource:shortName=cmsbc,scope=Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Used By",type=integer,JSONPath=`.status.modelServiceCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type ClusterModelServiceBaseConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BaseConfigSpec   `json:"spec,omitempty"`
+	Status BaseConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterModelServiceBaseConfigList contains a list of ClusterModelServiceBaseConfig.
+type ClusterModelServiceBaseConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterModelServiceBaseConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterModelServiceBaseConfig{}, &ClusterModelServiceBaseConfigList{})
+}
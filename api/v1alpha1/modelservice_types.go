@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	res "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -29,6 +30,10 @@ type ModelService struct {
 // ModelServiceSpec defines the desired state of ModelService
 type ModelServiceSpec struct {
 	// BaseConfigMapRef provides configuration needed to spawn objects owned by modelservice
+	// Kind selects the type of base config: ConfigMap (the default when empty),
+	// ModelServiceBaseConfig or ClusterModelServiceBaseConfig.
+	// Namespace defaults to the namespace of the modelservice and is ignored
+	// for ClusterModelServiceBaseConfig
 	//
 	// +optional
 	BaseConfigMapRef *corev1.ObjectReference `json:"baseConfigMapRef,omitempty"`
@@ -60,6 +65,104 @@ type ModelServiceSpec struct {
 	//
 	// +optional
 	EndpointPicker *ModelServicePodSpec `json:"endpointPicker,omitempty"`
+	// TemplateVars are user-defined values exposed to the base config
+	// templates as .Vars, e.g. {{ .Vars.maxNumSeqs }}. Values may be any JSON
+	//
+	// +optional
+	TemplateVars map[string]apiextensionsv1.JSON `json:"templateVars,omitempty"`
+	// Monitoring creates a Prometheus Operator PodMonitor scraping the
+	// metrics of the prefill and decode pods
+	//
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+	// Placement configures the topology-aware scheduling of the prefill and decode pods.
+	// The rendered affinities and topology spread constraints are merged with those of the base config
+	//
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+}
+
+// Placement configures the scheduling of the prefill and decode pods
+type Placement struct {
+	// Prefill configures the placement of the prefill pods
+	//
+	// +optional
+	Prefill *RolePlacement `json:"prefill,omitempty"`
+	// Decode configures the placement of the decode pods
+	//
+	// +optional
+	Decode *RolePlacement `json:"decode,omitempty"`
+	// PairAffinity prefers scheduling the prefill and decode pods of the ModelService
+	// in the same topology domain, such as a rack or an NVLink domain, so that
+	// the KV cache is transferred over the fastest links
+	//
+	// +optional
+	PairAffinity *PairAffinity `json:"pairAffinity,omitempty"`
+}
+
+// RolePlacement configures the placement of the pods of a role
+type RolePlacement struct {
+	// TopologySpreadConstraints of the pods of the role. The label selector
+	// defaults to the pods of the role of the ModelService
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// ReplicaAntiAffinity keeps the replicas of the role out of the same topology domain
+	//
+	// +optional
+	ReplicaAntiAffinity *ReplicaAntiAffinity `json:"replicaAntiAffinity,omitempty"`
+}
+
+// PairAffinity configures the preferred pod affinity between prefill and decode pods
+type PairAffinity struct {
+	// TopologyKey is the node label of the shared topology domain, such as a rack label
+	//
+	// +required
+	TopologyKey string `json:"topologyKey"`
+	// Weight of the preferred pod affinity
+	//
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// ReplicaAntiAffinity configures the pod anti-affinity between the replicas of a role
+type ReplicaAntiAffinity struct {
+	// TopologyKey is the node label of the topology domain. Defaults to kubernetes.io/hostname
+	//
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// Required makes the anti-affinity a scheduling requirement; it is preferred otherwise
+	//
+	// +optional
+	Required bool `json:"required,omitempty"`
+	// Weight of the preferred anti-affinity
+	//
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// Monitoring configures the PodMonitor of a ModelService
+type Monitoring struct {
+	// Port is the name of the routing port serving the metrics.
+	// Defaults to app_port, unless the podMonitor of the base config sets the port of its endpoints
+	//
+	// +optional
+	Port string `json:"port,omitempty"`
+	// Path is the path of the metrics endpoint. Defaults to /metrics
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Interval between scrapes, e.g. 30s. Defaults to the scrape interval of Prometheus
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	Interval string `json:"interval,omitempty"`
 }
 
 // ModelServiceList contains a list of ModelService
@@ -134,10 +237,39 @@ type ContainerSpec struct {
 	// For URIs with hf:// prefix, modelArtifact.authSecretName is used as the secret key reference,
 	// and the value is mounted to an environment variable called HF_TOKEN
 	// For URIs with oci:// prefix, an OCI volume with image reference (https://kubernetes.io/blog/2024/08/16/kubernetes-1-31-image-volume-source/)
-	// is created and mounted with the mountPath oci-dir
+	// is created and mounted read-only with the mountPath: /model-cache
 	// default:false
 	// +optional
 	MountModelVolume bool `json:"mountModelVolume,omitempty"`
+
+	// Ports of the container. A port replaces the base config port
+	// with the same containerPort and protocol
+	//
+	// +optional
+	// +listType=atomic
+	Ports []corev1.ContainerPort `json:"ports,omitempty"`
+	// VolumeMounts of the container. A mount replaces the base config mount
+	// with the same mountPath
+	//
+	// +optional
+	// +listType=atomic
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// LivenessProbe of the container, replaces the base config probe when present
+	//
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe of the container, replaces the base config probe when present
+	//
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// StartupProbe of the container, replaces the base config probe when present
+	//
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+	// SecurityContext of the container. Its fields override those of the base config
+	//
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // Routing provides the information needed to configure routing
@@ -258,21 +390,249 @@ type Routing struct {
 // ModelArtifacts describes the source of the model
 type ModelArtifacts struct {
 	// URI is the model URI
-	// Three types of URIs are support to enable models packaged as images (oci://<image-repo>/<image-name><:image-tag>),
-	// models downloaded from HuggingFace (hf://<model-repo>/<model-name>)
+	// Five types of URIs are support to enable models packaged as images (oci://<image-repo>/<image-name><:image-tag><::path/to/model>),
+	// models downloaded from HuggingFace (hf://<model-repo>/<model-name>),
+	// models downloaded from S3 compatible or Google Cloud Storage buckets (s3://<bucket>/<prefix>, gs://<bucket>/<prefix>)
 	// and pre-existing models loaded from a volume-mounted PVC (pvc://model-path)
 	//
 	// +required
 	URI string `json:"uri"`
-	// Name of the authentication secret. Contains HF_TOKEN
+	// Name of the authentication secret. Contains HF_TOKEN, or the key set by hfToken.secretKey, for hf:// URIs, and
+	// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and optionally AWS_DEFAULT_REGION
+	// for s3:// and gs:// URIs; gs:// URIs use HMAC keys
 	//
 	// +optional
 	AuthSecretName *string `json:"authSecretName,omitempty"`
+	// HFToken configures the Hugging Face token of hf:// URIs. By default, the
+	// HF_TOKEN key of authSecretName is injected into every container mounting the model volume
+	//
+	// +optional
+	HFToken *HFToken `json:"hfToken,omitempty"`
 	// Size of the model artifacts on disk
-	// ensure Size is large enough when providing hf://... URI
+	// ensure Size is large enough when providing hf://..., s3://... or gs://... URIs
 	//
 	// +optional
 	Size *res.Quantity `json:"size,omitempty"`
+	// Revision of the model to serve, as a branch, tag or commit of a hf:// URI.
+	// Defaults to main
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Endpoint overrides the object storage endpoint of s3:// and gs:// URIs,
+	// such as the URL of a MinIO server
+	//
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Download fetches a hf:// model in an init container before the model
+	// server starts, instead of letting the model server download it.
+	// s3:// and gs:// models are always downloaded by an init container;
+	// download configures it
+	//
+	// +optional
+	Download *ModelDownload `json:"download,omitempty"`
+	// Cache stores a hf://, s3:// or gs:// model on a PersistentVolumeClaim of size owned by the ModelService.
+	// A Job downloads the model once, and the prefill and decode deployments are
+	// only created or updated once it succeeds. The download settings are read from download
+	//
+	// +optional
+	Cache *ModelCache `json:"cache,omitempty"`
+	// WritableCache adds a writable volume to the containers mounting the model
+	// volume, at /writable-cache, for the caches written by the model server such
+	// as the vLLM, torch compile, triton and flashinfer caches. The model volume
+	// of pvc:// and oci:// URIs is read-only
+	//
+	// +optional
+	WritableCache *WritableCache `json:"writableCache,omitempty"`
+	// Verification checks the model artifacts before they are served. The
+	// prefill and decode pods do not become ready until every check passes,
+	// and the verified digest is recorded in status.verifiedDigest
+	//
+	// +optional
+	Verification *ModelVerification `json:"verification,omitempty"`
+}
+
+// ModelVerification configures the checks of the model artifacts
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.signature) || has(self.digest)",message="signature requires digest"
+type ModelVerification struct {
+	// Digest is the approved digest of the image of an oci:// URI, sha256:<hex>.
+	// The image volume is pulled by digest, and the controller holds the prefill
+	// and decode deployments until the image reference of the URI resolves to
+	// the digest in the registry. The registry is authenticated with the
+	// .dockerconfigjson of authSecretName, if set
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest,omitempty"`
+	// Manifest maps files of the model, relative to the mounted model path, to
+	// their expected SHA-256 digest. An init container of every prefill and decode
+	// pod checks them before the model server starts
+	//
+	// +optional
+	Manifest map[string]string `json:"manifest,omitempty"`
+	// Signature verifies the cosign signature of the image of an oci:// URI,
+	// in an init container of every prefill and decode pod
+	//
+	// +optional
+	Signature *SignatureVerification `json:"signature,omitempty"`
+	// Image of the init container checking the manifest; it must provide sh and sha256sum.
+	// Defaults to busybox
+	//
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// SignatureVerification configures the cosign verification of an image,
+// with a public key or keyless with the identity of its signing certificate
+//
+// +kubebuilder:validation:XValidation:rule="has(self.publicKey) != has(self.certificateIdentity)",message="exactly one of publicKey or certificateIdentity is required"
+// +kubebuilder:validation:XValidation:rule="has(self.certificateIdentity) == has(self.certificateOIDCIssuer)",message="certificateIdentity and certificateOIDCIssuer must be set together"
+type SignatureVerification struct {
+	// PublicKey is the PEM encoded public key the image is signed with
+	//
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+	// CertificateIdentity is the identity of the keyless signing certificate,
+	// such as the email or the workflow URL of the signer
+	//
+	// +optional
+	CertificateIdentity string `json:"certificateIdentity,omitempty"`
+	// CertificateOIDCIssuer is the OIDC issuer of the keyless signing certificate,
+	// such as https://token.actions.githubusercontent.com
+	//
+	// +optional
+	CertificateOIDCIssuer string `json:"certificateOIDCIssuer,omitempty"`
+	// Image of the init container running cosign. Defaults to the cosign release image
+	//
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// HFTokenInjection selects the containers the Hugging Face token is injected into
+//
+// +kubebuilder:validation:Enum=All;DownloadOnly
+type HFTokenInjection string
+
+const (
+	// HFTokenInjectionAll injects the token into the download init container or Job,
+	// and into every container mounting the model volume
+	HFTokenInjectionAll HFTokenInjection = "All"
+	// HFTokenInjectionDownloadOnly only injects the token into the download init
+	// container or Job, never into the long-running model server
+	HFTokenInjectionDownloadOnly HFTokenInjection = "DownloadOnly"
+)
+
+// HFToken configures where the Hugging Face token is read from, and which containers get it.
+// The token is read from the secretKey of authSecretName, unless serviceAccountToken or
+// secretProviderClass is set; those are mounted as a file pointed to by HF_TOKEN_PATH
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.serviceAccountToken) && has(self.secretProviderClass))",message="serviceAccountToken and secretProviderClass are mutually exclusive"
+type HFToken struct {
+	// SecretKey is the key of authSecretName holding the token. Defaults to HF_TOKEN
+	//
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// ServiceAccountToken uses a projected token of the service account of the pod,
+	// for Hugging Face endpoints or mirrors accepting Kubernetes service account tokens
+	//
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenProjection `json:"serviceAccountToken,omitempty"`
+	// SecretProviderClass reads the token from the Secrets Store CSI driver
+	//
+	// +optional
+	SecretProviderClass *SecretProviderClassSource `json:"secretProviderClass,omitempty"`
+	// Injection selects the containers the token is injected into. DownloadOnly
+	// requires the model to be downloaded by modelArtifacts.download or modelArtifacts.cache
+	//
+	// +optional
+	// +kubebuilder:default=All
+	Injection HFTokenInjection `json:"injection,omitempty"`
+}
+
+// ServiceAccountTokenProjection configures a projected service account token
+type ServiceAccountTokenProjection struct {
+	// Audience of the token
+	//
+	// +required
+	Audience string `json:"audience"`
+	// ExpirationSeconds is the requested lifetime of the token; the kubelet rotates it.
+	// Defaults to one hour
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// SecretProviderClassSource mounts a file of a SecretProviderClass of the Secrets Store CSI driver
+type SecretProviderClassSource struct {
+	// Name of the SecretProviderClass, in the namespace of the ModelService
+	//
+	// +required
+	Name string `json:"name"`
+	// File is the object name of the token in the SecretProviderClass. Defaults to token
+	//
+	// +optional
+	File string `json:"file,omitempty"`
+}
+
+// WritableCache configures the writable cache volume of the model server
+type WritableCache struct {
+	// ClaimName is an existing PersistentVolumeClaim holding the cache, so
+	// that it outlives the pods. An emptyDir is used if empty
+	//
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+	// Size limits the emptyDir used if claimName is empty
+	//
+	// +optional
+	Size *res.Quantity `json:"size,omitempty"`
+}
+
+// ModelCache configures the PersistentVolumeClaim caching a downloaded model
+type ModelCache struct {
+	// StorageClassName of the PersistentVolumeClaim; the default storage class is used if empty
+	//
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// AccessMode of the PersistentVolumeClaim. ReadWriteMany lets pods on
+	// every node mount the cache
+	//
+	// +optional
+	// +kubebuilder:default=ReadWriteMany
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+}
+
+// ModelDownload configures the init container downloading a model
+type ModelDownload struct {
+	// Image of the init container; it must provide sha256sum and huggingface-cli
+	// for hf:// URIs, or the AWS CLI for s3:// and gs:// URIs.
+	// For hf:// URIs, defaults to the image of the first container mounting the
+	// model volume, which is usually the model server image. For s3:// and gs:// URIs,
+	// defaults to the AWS CLI image
+	//
+	// +optional
+	Image string `json:"image,omitempty"`
+	// AllowPatterns restricts the downloaded files to those matching one of these glob patterns
+	//
+	// +optional
+	AllowPatterns []string `json:"allowPatterns,omitempty"`
+	// IgnorePatterns excludes the files matching one of these glob patterns
+	//
+	// +optional
+	IgnorePatterns []string `json:"ignorePatterns,omitempty"`
+	// Checksums maps files of the model, relative to its root, to their
+	// expected SHA-256 digest. The download fails if one of them does not match
+	//
+	// +optional
+	Checksums map[string]string `json:"checksums,omitempty"`
+	// CacheClaimName is an existing ReadWriteMany PersistentVolumeClaim shared
+	// by ModelServices. Models are stored under <repo-id>/<model-id>/<revision>,
+	// so that pods of any ModelService serving the same revision start from the
+	// cache. An emptyDir sized by size is used if empty
+	//
+	// +optional
+	CacheClaimName string `json:"cacheClaimName,omitempty"`
 }
 
 // ModelServicePodSpec defines the specification for pod templates that will be created by ModelService.
@@ -292,6 +652,42 @@ type ModelServicePodSpec struct {
 	//
 	// +optional
 	InitContainers []ContainerSpec `json:"initContainers,omitempty"`
+
+	// PodLabels are added to the labels of the pod template, over those of the base config.
+	// The labels set by the controller take precedence
+	//
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// PodAnnotations are added to the annotations of the pod template, over those of the base config
+	//
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// NodeSelector is added to the node selector of the base config, over its keys
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the tolerations of the base config
+	//
+	// +optional
+	// +listType=atomic
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PriorityClassName of the pods, overrides the base config when present
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// RuntimeClassName of the pods, overrides the base config when present
+	//
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// SchedulerName of the pods, overrides the base config when present
+	//
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// ImagePullSecrets are added to the image pull secrets of the base config
+	//
+	// +optional
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
 // PDSpec defines the specification for prefill and decode deployments created by ModelService.
@@ -345,8 +741,82 @@ type AcceleratorTypes struct {
 	// +required
 	// +kubebuilder:validation:MinItems=1
 	LabelValues []string `json:"labelValues,omitempty"`
+	// Preference is how the scheduler chooses between the label values.
+	// Any treats them equally; Ordered prefers them in the order of labelValues,
+	// e.g., H100 if available, else A100. Pods are never scheduled on
+	// nodes without one of the label values
+	//
+	// +optional
+	// +kubebuilder:default=Any
+	Preference AcceleratorPreference `json:"preference,omitempty"`
+	// Overrides adjust the pods for some of the label values, e.g. a tensor
+	// parallelism of 2 on A100 where H100 needs 1. Each override is rendered
+	// as its own Deployment, named after the Deployment of the role and the
+	// label value, whose pods only run on nodes with that label value.
+	// The Deployment of the role runs on the other label values, and is
+	// scaled to zero if every label value has an override
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=labelValue
+	Overrides []AcceleratorOverride `json:"overrides,omitempty"`
+}
+
+// AcceleratorOverride adjusts the pods of a role on one accelerator type
+type AcceleratorOverride struct {
+	// LabelValue is the accelerator type of the override, one of labelValues
+	//
+	// +required
+	LabelValue string `json:"labelValue"`
+	// Replicas of the pods on LabelValue, 1 if unset.
+	// Ignored once the Deployment exists if scaling is decoupled
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Parallelism replaces the parallelism of the role on LabelValue,
+	// including in the templates of the base config
+	//
+	// +optional
+	Parallelism *Parallelism `json:"parallelism,omitempty"`
+	// Containers adjust the containers of the role with the same name,
+	// whether they come from the ModelService or from the base config
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Containers []AcceleratorContainerOverride `json:"containers,omitempty"`
 }
 
+// AcceleratorContainerOverride adjusts a container on one accelerator type
+type AcceleratorContainerOverride struct {
+	// Name of the container
+	//
+	// +required
+	Name string `json:"name"`
+	// Args replace the args of the container in the ModelService when present.
+	// They are templated like the args of the container
+	//
+	// +optional
+	// +listType=atomic
+	Args []string `json:"args,omitempty"`
+	// Resources replace the resources of the container in the ModelService when present
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// AcceleratorPreference is how the scheduler chooses between accelerator types
+// +kubebuilder:validation:Enum=Any;Ordered
+type AcceleratorPreference string
+
+const (
+	// AcceleratorPreferenceAny schedules pods on any of the accelerator types
+	AcceleratorPreferenceAny AcceleratorPreference = "Any"
+	// AcceleratorPreferenceOrdered prefers the accelerator types in their order
+	AcceleratorPreferenceOrdered AcceleratorPreference = "Ordered"
+)
+
 // ModelServiceStatus defines the observed state of ModelService
 type ModelServiceStatus struct {
 	// PrefillDeploymentRef identifies the prefill deployment
@@ -398,12 +868,44 @@ type ModelServiceStatus struct {
 	//
 	EppRoleBinding *string `json:"eppRoleBinding,omitempty"`
 	//
+	// PodMonitorRef identifies the PodMonitor scraping the prefill and decode pods
+	// if monitoring is not configured, or if the PodMonitor is yet to be created,
+	// this reference will be nil
+	//
+	PodMonitorRef *string `json:"podMonitorRef,omitempty"`
+	//
 	// ConfigMapNames identifies the configmap used for prefill and decode
 	// if ConfigMapNames is yet to be created,
 	// this reference will be an empty list
 	//
 	ConfigMapNames []string `json:"configMapNames,omitempty"`
 
+	// ObservedGeneration is the generation of the ModelService last applied
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//
+	// BaseConfigRevision identifies the content of the base config, and of its
+	// parents, the child resources were last rendered from
+	//
+	// +optional
+	BaseConfigRevision string `json:"baseConfigRevision,omitempty"`
+	//
+	// BaseConfigChain lists the base config referenced by baseConfigMapRef and
+	// its parents, root first, as last resolved. A base config that could not be
+	// read or was not permitted is listed too, so that its creation is noticed
+	//
+	// +optional
+	BaseConfigChain []BaseConfigLayerReference `json:"baseConfigChain,omitempty"`
+	//
+	// VerifiedDigest is the digest of the model artifacts verified by
+	// modelArtifacts.verification: the image digest of oci:// URIs, or the
+	// sha256:<hex> digest of the manifest otherwise. It is empty until the
+	// verification passes
+	//
+	// +optional
+	VerifiedDigest string `json:"verifiedDigest,omitempty"`
+
 	// READY and AVAILABLE for prefill
 	PrefillReady     string `json:"prefillReady"` // e.g. "1/1"
 	PrefillAvailable int32  `json:"prefillAvailable"`
@@ -422,6 +924,18 @@ type ModelServiceStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// BaseConfigLayerReference identifies one base config of the inheritance chain of a ModelService
+type BaseConfigLayerReference struct {
+	// Kind of the base config: ConfigMap, ModelServiceBaseConfig or ClusterModelServiceBaseConfig
+	Kind string `json:"kind"`
+	// Namespace of the base config, empty for a ClusterModelServiceBaseConfig
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the base config
+	Name string `json:"name"`
+}
+
 type Port struct {
 	// Name that can be used in place of port number in templates
 	// +required
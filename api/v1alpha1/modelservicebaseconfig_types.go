@@ -0,0 +1,220 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	giev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+)
+
+const (
+	// ModelServiceBaseConfigKind is the kind of a namespaced base config
+	ModelServiceBaseConfigKind = "ModelServiceBaseConfig"
+	// ClusterModelServiceBaseConfigKind is the kind of a cluster-scoped base config
+	ClusterModelServiceBaseConfigKind = "ClusterModelServiceBaseConfig"
+	// ConfigMapKind is the kind of a base config held in a ConfigMap
+	ConfigMapKind = "ConfigMap"
+)
+
+// ModelServiceBaseConfig is the Schema for the modelservicebaseconfigs API.
+// It holds the same platform defaults as a base config ConfigMap, with typed fields.
+//
+// +kubebuilder:resource:shortName=msbc,scope=Namespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Used By",type=integer,JSONPath=`.status.modelServiceCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type ModelServiceBaseConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BaseConfigSpec   `json:"spec,omitempty"`
+	Status BaseConfigStatus `json:"status,omitempty"`
+}
+
+// TemplateVarDeclaration declares a variable of ModelService.spec.templateVars
+// used by a base config
+type TemplateVarDeclaration struct {
+	// Required variables must be set by the ModelService unless they have a default
+	//
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Default is used when the ModelService does not set the variable
+	//
+	// +optional
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+
+	// Description documents the variable for ModelService owners
+	//
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// BaseConfigOverlay is a structured patch applied to one base config key.
+// String values of the form ${ expression } are CEL expressions evaluated
+// against the ModelService; their result replaces the string
+type BaseConfigOverlay struct {
+	// StrategicMerge is a strategic merge patch, as used by kubectl patch.
+	// It is applied before JSONPatch
+	//
+	// +optional
+	StrategicMerge *apiextensionsv1.JSON `json:"strategicMerge,omitempty"`
+
+	// JSONPatch is an RFC 6902 JSON Patch
+	//
+	// +optional
+	JSONPatch []apiextensionsv1.JSON `json:"jsonPatch,omitempty"`
+}
+
+// PropagationPolicy limits how many ModelServices referencing a base config
+// roll out a change of the base config at once
+type PropagationPolicy struct {
+	// MaxRolling is the number, or percentage, of the ModelServices referencing
+	// the base config that may roll out a change at once. Percentages are
+	// rounded up; at least one ModelService rolls out at a time
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Pattern="^(0|[1-9][0-9]*)%?$"
+	MaxRolling intstr.IntOrString `json:"maxRolling"`
+}
+
+// BaseConfigSpec defines the child resources that ModelServices referencing
+// this base config are merged into. Each field mirrors the key of the same
+// name in a base config ConfigMap.
+// String fields may contain the same template variables as a ConfigMap;
+// numeric fields cannot be templated.
+//
+// The deployments and the http route are stored without a schema, since their
+// full schemas would make the CRD larger than the API server accepts.
+// They are decoded strictly by the controller instead, and decoding errors
+// are reported on the ModelServices using this base config
+type BaseConfigSpec struct {
+	// Parent references the base config this one is layered on top of.
+	// Kind is ConfigMap (the default when empty), ModelServiceBaseConfig or
+	// ClusterModelServiceBaseConfig. Namespace defaults to the namespace of
+	// this base config, or of the ModelService for a ClusterModelServiceBaseConfig
+	//
+	// +optional
+	Parent *corev1.ObjectReference `json:"parent,omitempty"`
+
+	// TemplateVars declares the ModelService template variables used by this
+	// base config, with their defaults
+	//
+	// +optional
+	TemplateVars map[string]TemplateVarDeclaration `json:"templateVars,omitempty"`
+
+	// Overlays are structured patches applied to the keys of this base config,
+	// by key, after the Go templates are rendered
+	//
+	// +optional
+	Overlays map[string]BaseConfigOverlay `json:"overlays,omitempty"`
+
+	// Propagation limits how many ModelServices referencing this base config
+	// roll out its changes at once. All of them are updated at once if unset
+	//
+	// +optional
+	Propagation *PropagationPolicy `json:"propagation,omitempty"`
+	// ConfigMaps are created as is, with the ModelService as owner
+	//
+	// +optional
+	ConfigMaps []corev1.ConfigMap `json:"configMaps,omitempty"`
+	// PrefillDeployment is the base for the prefill deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PrefillDeployment *runtime.RawExtension `json:"prefillDeployment,omitempty"`
+	// DecodeDeployment is the base for the decode deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	DecodeDeployment *runtime.RawExtension `json:"decodeDeployment,omitempty"`
+	// PrefillService is the base for the prefill service
+	//
+	// +optional
+	PrefillService *corev1.Service `json:"prefillService,omitempty"`
+	// DecodeService is the base for the decode service
+	//
+	// +optional
+	DecodeService *corev1.Service `json:"decodeService,omitempty"`
+	// HTTPRoute is the base for the http route
+	//
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	HTTPRoute *runtime.RawExtension `json:"httpRoute,omitempty"`
+	// InferencePool is the base for the inference pool
+	//
+	// +optional
+	InferencePool *giev1alpha2.InferencePool `json:"inferencePool,omitempty"`
+	// InferenceModel is the base for the inference model
+	//
+	// +optional
+	InferenceModel *giev1alpha2.InferenceModel `json:"inferenceModel,omitempty"`
+	// EPPDeployment is the base for the endpoint picker deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	EPPDeployment *runtime.RawExtension `json:"eppDeployment,omitempty"`
+	// EPPService is the base for the endpoint picker service
+	//
+	// +optional
+	EPPService *corev1.Service `json:"eppService,omitempty"`
+	// EPPServiceAccount is the base for the endpoint picker service account
+	//
+	// +optional
+	EPPServiceAccount *corev1.ServiceAccount `json:"eppServiceAccount,omitempty"`
+	// PDServiceAccount is the base for the prefill and decode service account
+	//
+	// +optional
+	PDServiceAccount *corev1.ServiceAccount `json:"pdServiceAccount,omitempty"`
+	// EPPRoleBinding is the base for the endpoint picker role binding
+	//
+	// +optional
+	EPPRoleBinding *rbacv1.RoleBinding `json:"eppRoleBinding,omitempty"`
+	// PodMonitor is the base for the Prometheus Operator PodMonitor
+	//
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PodMonitor *runtime.RawExtension `json:"podMonitor,omitempty"`
+}
+
+// BaseConfigStatus defines the observed state of a base config
+type BaseConfigStatus struct {
+	// ModelServices lists the ModelServices referencing this base config
+	//
+	// +optional
+	ModelServices []ModelServiceReference `json:"modelServices,omitempty"`
+	// ModelServiceCount is the number of ModelServices referencing this base config
+	//
+	// +optional
+	ModelServiceCount int32 `json:"modelServiceCount,omitempty"`
+}
+
+// ModelServiceReference identifies a ModelService
+type ModelServiceReference struct {
+	// Namespace of the ModelService
+	Namespace string `json:"namespace"`
+	// Name of the ModelService
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelServiceBaseConfigList contains a list of ModelServiceBaseConfig.
+type ModelServiceBaseConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelServiceBaseConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelServiceBaseConfig{}, &ModelServiceBaseConfigList{})
+}
@@ -6,11 +6,71 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorContainerOverride) DeepCopyInto(out *AcceleratorContainerOverride) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorContainerOverride.
+func (in *AcceleratorContainerOverride) DeepCopy() *AcceleratorContainerOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorContainerOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorOverride) DeepCopyInto(out *AcceleratorOverride) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(Parallelism)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]AcceleratorContainerOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorOverride.
+func (in *AcceleratorOverride) DeepCopy() *AcceleratorOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AcceleratorTypes) DeepCopyInto(out *AcceleratorTypes) {
 	*out = *in
@@ -19,6 +79,13 @@ func (in *AcceleratorTypes) DeepCopyInto(out *AcceleratorTypes) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]AcceleratorOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorTypes.
@@ -31,6 +98,351 @@ func (in *AcceleratorTypes) DeepCopy() *AcceleratorTypes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigGrant) DeepCopyInto(out *BaseConfigGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigGrant.
+func (in *BaseConfigGrant) DeepCopy() *BaseConfigGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BaseConfigGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigGrantFrom) DeepCopyInto(out *BaseConfigGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigGrantFrom.
+func (in *BaseConfigGrantFrom) DeepCopy() *BaseConfigGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigGrantList) DeepCopyInto(out *BaseConfigGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BaseConfigGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigGrantList.
+func (in *BaseConfigGrantList) DeepCopy() *BaseConfigGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BaseConfigGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigGrantSpec) DeepCopyInto(out *BaseConfigGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]BaseConfigGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]BaseConfigGrantTo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigGrantSpec.
+func (in *BaseConfigGrantSpec) DeepCopy() *BaseConfigGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigGrantTo) DeepCopyInto(out *BaseConfigGrantTo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigGrantTo.
+func (in *BaseConfigGrantTo) DeepCopy() *BaseConfigGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigLayerReference) DeepCopyInto(out *BaseConfigLayerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigLayerReference.
+func (in *BaseConfigLayerReference) DeepCopy() *BaseConfigLayerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigLayerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigOverlay) DeepCopyInto(out *BaseConfigOverlay) {
+	*out = *in
+	if in.StrategicMerge != nil {
+		in, out := &in.StrategicMerge, &out.StrategicMerge
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JSONPatch != nil {
+		in, out := &in.JSONPatch, &out.JSONPatch
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigOverlay.
+func (in *BaseConfigOverlay) DeepCopy() *BaseConfigOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigSpec) DeepCopyInto(out *BaseConfigSpec) {
+	*out = *in
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.TemplateVars != nil {
+		in, out := &in.TemplateVars, &out.TemplateVars
+		*out = make(map[string]TemplateVarDeclaration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Overlays != nil {
+		in, out := &in.Overlays, &out.Overlays
+		*out = make(map[string]BaseConfigOverlay, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Propagation != nil {
+		in, out := &in.Propagation, &out.Propagation
+		*out = new(PropagationPolicy)
+		**out = **in
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]v1.ConfigMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrefillDeployment != nil {
+		in, out := &in.PrefillDeployment, &out.PrefillDeployment
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DecodeDeployment != nil {
+		in, out := &in.DecodeDeployment, &out.DecodeDeployment
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrefillService != nil {
+		in, out := &in.PrefillService, &out.PrefillService
+		*out = new(v1.Service)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DecodeService != nil {
+		in, out := &in.DecodeService, &out.DecodeService
+		*out = new(v1.Service)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InferencePool != nil {
+		in, out := &in.InferencePool, &out.InferencePool
+		*out = new(v1alpha2.InferencePool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InferenceModel != nil {
+		in, out := &in.InferenceModel, &out.InferenceModel
+		*out = new(v1alpha2.InferenceModel)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EPPDeployment != nil {
+		in, out := &in.EPPDeployment, &out.EPPDeployment
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EPPService != nil {
+		in, out := &in.EPPService, &out.EPPService
+		*out = new(v1.Service)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EPPServiceAccount != nil {
+		in, out := &in.EPPServiceAccount, &out.EPPServiceAccount
+		*out = new(v1.ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDServiceAccount != nil {
+		in, out := &in.PDServiceAccount, &out.PDServiceAccount
+		*out = new(v1.ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EPPRoleBinding != nil {
+		in, out := &in.EPPRoleBinding, &out.EPPRoleBinding
+		*out = new(rbacv1.RoleBinding)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodMonitor != nil {
+		in, out := &in.PodMonitor, &out.PodMonitor
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigSpec.
+func (in *BaseConfigSpec) DeepCopy() *BaseConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigStatus) DeepCopyInto(out *BaseConfigStatus) {
+	*out = *in
+	if in.ModelServices != nil {
+		in, out := &in.ModelServices, &out.ModelServices
+		*out = make([]ModelServiceReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigStatus.
+func (in *BaseConfigStatus) DeepCopy() *BaseConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterModelServiceBaseConfig) DeepCopyInto(out *ClusterModelServiceBaseConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterModelServiceBaseConfig.
+func (in *ClusterModelServiceBaseConfig) DeepCopy() *ClusterModelServiceBaseConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterModelServiceBaseConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterModelServiceBaseConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterModelServiceBaseConfigList) DeepCopyInto(out *ClusterModelServiceBaseConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterModelServiceBaseConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterModelServiceBaseConfigList.
+func (in *ClusterModelServiceBaseConfigList) DeepCopy() *ClusterModelServiceBaseConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterModelServiceBaseConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterModelServiceBaseConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerSpec) DeepCopyInto(out *ContainerSpec) {
 	*out = *in
@@ -64,64 +476,257 @@ func (in *ContainerSpec) DeepCopyInto(out *ContainerSpec) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1.ContainerPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerSpec.
+func (in *ContainerSpec) DeepCopy() *ContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HFToken) DeepCopyInto(out *HFToken) {
+	*out = *in
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ServiceAccountTokenProjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretProviderClass != nil {
+		in, out := &in.SecretProviderClass, &out.SecretProviderClass
+		*out = new(SecretProviderClassSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HFToken.
+func (in *HFToken) DeepCopy() *HFToken {
+	if in == nil {
+		return nil
+	}
+	out := new(HFToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelArtifacts) DeepCopyInto(out *ModelArtifacts) {
+	*out = *in
+	if in.AuthSecretName != nil {
+		in, out := &in.AuthSecretName, &out.AuthSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.HFToken != nil {
+		in, out := &in.HFToken, &out.HFToken
+		*out = new(HFToken)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = new(ModelDownload)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ModelCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WritableCache != nil {
+		in, out := &in.WritableCache, &out.WritableCache
+		*out = new(WritableCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(ModelVerification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelArtifacts.
+func (in *ModelArtifacts) DeepCopy() *ModelArtifacts {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelArtifacts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCache) DeepCopyInto(out *ModelCache) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCache.
+func (in *ModelCache) DeepCopy() *ModelCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDownload) DeepCopyInto(out *ModelDownload) {
+	*out = *in
+	if in.AllowPatterns != nil {
+		in, out := &in.AllowPatterns, &out.AllowPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePatterns != nil {
+		in, out := &in.IgnorePatterns, &out.IgnorePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Checksums != nil {
+		in, out := &in.Checksums, &out.Checksums
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDownload.
+func (in *ModelDownload) DeepCopy() *ModelDownload {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDownload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelService) DeepCopyInto(out *ModelService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerSpec.
-func (in *ContainerSpec) DeepCopy() *ContainerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelService.
+func (in *ModelService) DeepCopy() *ModelService {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerSpec)
+	out := new(ModelService)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelArtifacts) DeepCopyInto(out *ModelArtifacts) {
+func (in *ModelServiceBaseConfig) DeepCopyInto(out *ModelServiceBaseConfig) {
 	*out = *in
-	if in.AuthSecretName != nil {
-		in, out := &in.AuthSecretName, &out.AuthSecretName
-		*out = new(string)
-		**out = **in
-	}
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		x := (*in).DeepCopy()
-		*out = &x
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelArtifacts.
-func (in *ModelArtifacts) DeepCopy() *ModelArtifacts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServiceBaseConfig.
+func (in *ModelServiceBaseConfig) DeepCopy() *ModelServiceBaseConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelArtifacts)
+	out := new(ModelServiceBaseConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelServiceBaseConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelService) DeepCopyInto(out *ModelService) {
+func (in *ModelServiceBaseConfigList) DeepCopyInto(out *ModelServiceBaseConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelServiceBaseConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelService.
-func (in *ModelService) DeepCopy() *ModelService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServiceBaseConfigList.
+func (in *ModelServiceBaseConfigList) DeepCopy() *ModelServiceBaseConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelService)
+	out := new(ModelServiceBaseConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelService) DeepCopyObject() runtime.Object {
+func (in *ModelServiceBaseConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -182,6 +787,44 @@ func (in *ModelServicePodSpec) DeepCopyInto(out *ModelServicePodSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServicePodSpec.
@@ -194,6 +837,21 @@ func (in *ModelServicePodSpec) DeepCopy() *ModelServicePodSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelServiceReference) DeepCopyInto(out *ModelServiceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServiceReference.
+func (in *ModelServiceReference) DeepCopy() *ModelServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelServiceSpec) DeepCopyInto(out *ModelServiceSpec) {
 	*out = *in
@@ -219,6 +877,23 @@ func (in *ModelServiceSpec) DeepCopyInto(out *ModelServiceSpec) {
 		*out = new(ModelServicePodSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TemplateVars != nil {
+		in, out := &in.TemplateVars, &out.TemplateVars
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		**out = **in
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServiceSpec.
@@ -279,11 +954,21 @@ func (in *ModelServiceStatus) DeepCopyInto(out *ModelServiceStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodMonitorRef != nil {
+		in, out := &in.PodMonitorRef, &out.PodMonitorRef
+		*out = new(string)
+		**out = **in
+	}
 	if in.ConfigMapNames != nil {
 		in, out := &in.ConfigMapNames, &out.ConfigMapNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BaseConfigChain != nil {
+		in, out := &in.BaseConfigChain, &out.BaseConfigChain
+		*out = make([]BaseConfigLayerReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -303,6 +988,48 @@ func (in *ModelServiceStatus) DeepCopy() *ModelServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelVerification) DeepCopyInto(out *ModelVerification) {
+	*out = *in
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(SignatureVerification)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelVerification.
+func (in *ModelVerification) DeepCopy() *ModelVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDSpec) DeepCopyInto(out *PDSpec) {
 	*out = *in
@@ -329,6 +1056,21 @@ func (in *PDSpec) DeepCopy() *PDSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PairAffinity) DeepCopyInto(out *PairAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PairAffinity.
+func (in *PairAffinity) DeepCopy() *PairAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(PairAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Parallelism) DeepCopyInto(out *Parallelism) {
 	*out = *in
@@ -349,6 +1091,36 @@ func (in *Parallelism) DeepCopy() *Parallelism {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Placement) DeepCopyInto(out *Placement) {
+	*out = *in
+	if in.Prefill != nil {
+		in, out := &in.Prefill, &out.Prefill
+		*out = new(RolePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Decode != nil {
+		in, out := &in.Decode, &out.Decode
+		*out = new(RolePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PairAffinity != nil {
+		in, out := &in.PairAffinity, &out.PairAffinity
+		*out = new(PairAffinity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Placement.
+func (in *Placement) DeepCopy() *Placement {
+	if in == nil {
+		return nil
+	}
+	out := new(Placement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Port) DeepCopyInto(out *Port) {
 	*out = *in
@@ -364,6 +1136,64 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropagationPolicy) DeepCopyInto(out *PropagationPolicy) {
+	*out = *in
+	out.MaxRolling = in.MaxRolling
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationPolicy.
+func (in *PropagationPolicy) DeepCopy() *PropagationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PropagationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaAntiAffinity) DeepCopyInto(out *ReplicaAntiAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaAntiAffinity.
+func (in *ReplicaAntiAffinity) DeepCopy() *ReplicaAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolePlacement) DeepCopyInto(out *RolePlacement) {
+	*out = *in
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplicaAntiAffinity != nil {
+		in, out := &in.ReplicaAntiAffinity, &out.ReplicaAntiAffinity
+		*out = new(ReplicaAntiAffinity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePlacement.
+func (in *RolePlacement) DeepCopy() *RolePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(RolePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Routing) DeepCopyInto(out *Routing) {
 	*out = *in
@@ -390,3 +1220,93 @@ func (in *Routing) DeepCopy() *Routing {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderClassSource) DeepCopyInto(out *SecretProviderClassSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderClassSource.
+func (in *SecretProviderClassSource) DeepCopy() *SecretProviderClassSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderClassSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenProjection) DeepCopyInto(out *ServiceAccountTokenProjection) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenProjection.
+func (in *ServiceAccountTokenProjection) DeepCopy() *ServiceAccountTokenProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignatureVerification) DeepCopyInto(out *SignatureVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignatureVerification.
+func (in *SignatureVerification) DeepCopy() *SignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(SignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateVarDeclaration) DeepCopyInto(out *TemplateVarDeclaration) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateVarDeclaration.
+func (in *TemplateVarDeclaration) DeepCopy() *TemplateVarDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateVarDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WritableCache) DeepCopyInto(out *WritableCache) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WritableCache.
+func (in *WritableCache) DeepCopy() *WritableCache {
+	if in == nil {
+		return nil
+	}
+	out := new(WritableCache)
+	in.DeepCopyInto(out)
+	return out
+}
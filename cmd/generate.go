@@ -2,26 +2,31 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	zaplog "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/yaml"
 
 	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
-	"github.com/llm-d/llm-d-model-service/internal/controller"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	giev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
-func readModelService(ctx context.Context, filename string, logger logr.Logger) (*msv1alpha1.ModelService, error) {
+func readModelService(filename string, logger logr.Logger) (*msv1alpha1.ModelService, error) {
 	var modelService msv1alpha1.ModelService
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -35,87 +40,163 @@ func readModelService(ctx context.Context, filename string, logger logr.Logger)
 		return nil, err
 	}
 
-	// interpolate MSVC
-	return controller.InterpolateModelService(ctx, &modelService)
+	return &modelService, nil
 }
 
-func getBaseChildResources(filename string, msvc *msv1alpha1.ModelService, logger logr.Logger) (*controller.BaseConfig, error) {
-	var baseChildResourcesConfigMap *corev1.ConfigMap
-	var baseChildResources *controller.BaseConfig
+func readBaseConfigMap(filename string, logger logr.Logger) (*corev1.ConfigMap, string, error) {
+	var baseConfigMap *corev1.ConfigMap
 
-	if filename != "" {
-		data, err := os.ReadFile(filename)
-		if err != nil {
-			if os.IsNotExist(err) {
-				logger.Error(err, "unable to read base child resources from "+filename)
-				return nil, err
-			}
-			data = []byte{}
+	if filename == "" {
+		return nil, "", nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Error(err, "unable to read base child resources from "+filename)
+			return nil, "", err
 		}
+		data = []byte{}
+	}
 
-		err = yaml.Unmarshal(data, &baseChildResourcesConfigMap)
-		if err != nil {
-			logger.Error(err, "unable to unmarshal base child resources")
-			return nil, err
+	// the base config may also be a ModelServiceBaseConfig or a ClusterModelServiceBaseConfig
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err == nil {
+		switch typeMeta.Kind {
+		case msv1alpha1.ModelServiceBaseConfigKind, msv1alpha1.ClusterModelServiceBaseConfigKind:
+			var baseConfig msv1alpha1.ModelServiceBaseConfig
+			if err := yaml.Unmarshal(data, &baseConfig); err != nil {
+				logger.Error(err, "unable to unmarshal base config", "kind", typeMeta.Kind)
+				return nil, "", err
+			}
+			cm, err := render.ConfigMapFromBaseConfigSpec(baseConfig.ObjectMeta, &baseConfig.Spec)
+			return cm, typeMeta.Kind, err
 		}
-	} else {
-		baseChildResourcesConfigMap = &corev1.ConfigMap{}
 	}
 
-	interpolated, err := controller.InterpolateBaseConfigMap(context.TODO(), baseChildResourcesConfigMap, msvc)
+	err = yaml.Unmarshal(data, &baseConfigMap)
 	if err != nil {
-		logger.Error(err, "cannot interpolate base configmap")
-		return nil, err
+		logger.Error(err, "unable to unmarshal base child resources")
+		return nil, "", err
 	}
 
-	baseChildResources, err = controller.BaseConfigFromCM(interpolated)
-	if err != nil {
-		logger.Error(err, "unable to create base child resources from config map")
+	return baseConfigMap, msv1alpha1.ConfigMapKind, nil
+}
+
+// readBaseConfigChain reads the base config in configFile and resolves its
+// parents against the base configs in parentFiles, matched by kind and name.
+// The layers are returned root first
+func readBaseConfigChain(ctx context.Context, namespace string, configFile string, parentFiles []string, logger logr.Logger) ([]render.BaseConfigLayer, error) {
+	baseConfigMap, kind, err := readBaseConfigMap(configFile, logger)
+	if err != nil || baseConfigMap == nil {
 		return nil, err
 	}
 
-	return baseChildResources, nil
+	// base configs are looked up by kind and name; namespaces are ignored
+	available := map[string]*corev1.ConfigMap{}
+	for _, parentFile := range parentFiles {
+		parent, parentKind, err := readBaseConfigMap(parentFile, logger)
+		if err != nil {
+			logger.Error(err, "unable to read parent base config", "location", parentFile)
+			return nil, err
+		}
+		available[parentKind+"/"+parent.Name] = parent
+	}
+	available[kind+"/"+baseConfigMap.Name] = baseConfigMap
+
+	get := func(_ context.Context, ref *corev1.ObjectReference, _ string) (*corev1.ConfigMap, error) {
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = msv1alpha1.ConfigMapKind
+		}
+		cm, ok := available[refKind+"/"+ref.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s %q not found; pass it with --parent", refKind, ref.Name)
+		}
+		return cm, nil
+	}
+
+	return render.ResolveBaseConfigChain(ctx, &corev1.ObjectReference{Kind: kind, Name: baseConfigMap.Name}, namespace, get)
 }
 
-func generateManifests(ctx context.Context, manifestFile string, configFile string) (*string, error) {
+// readInputs reads the ModelService and its base config layers, and
+// registers the types of the child resources
+func readInputs(ctx context.Context, manifestFile string, configFile string, parentFiles []string) (*msv1alpha1.ModelService, []render.BaseConfigLayer, error) {
 	logger := log.FromContext(ctx)
 
-	// get msvc from file and interpolate it
-	msvc, err := readModelService(ctx, manifestFile, logger)
+	// get msvc from file
+	msvc, err := readModelService(manifestFile, logger)
 	if err != nil {
 		logger.Error(err, "unable to read ModelService", "location", manifestFile)
-		return nil, err
+		return nil, nil, err
 	}
 	logger.V(1).Info("generateManifest", "modelService", msvc)
 
-	// get base child resources from file
-	config, err := getBaseChildResources(configFile, msvc, logger)
+	// get base config and its parents from files
+	layers, err := readBaseConfigChain(ctx, msvc.Namespace, configFile, parentFiles, logger)
 	if err != nil {
 		logger.Error(err, "unable to read basic configuration", "location", configFile)
-		return nil, err
+		return nil, nil, err
+	}
+	for _, layer := range layers {
+		for _, key := range render.UnknownBaseConfigKeys(layer.ConfigMap) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring unknown base config key %q in %s\n", key, layer.Name)
+		}
 	}
-	logger.V(1).Info("generateManifest", "baseResources", config)
 
 	// create scheme
 	err = msv1alpha1.AddToScheme(scheme.Scheme)
 	if err != nil {
 		logger.Info("unable to add model service to scheme")
-		return nil, err
+		return nil, nil, err
 	}
 	err = gatewayv1.Install(scheme.Scheme)
 	if err != nil {
 		logger.Info("unable to add gateway api extension to scheme")
-		return nil, err
+		return nil, nil, err
 	}
 	err = giev1alpha2.Install(scheme.Scheme)
 	if err != nil {
 		logger.Info("unable to add gateway api extension to scheme")
+		return nil, nil, err
+	}
+	err = monitoringv1.AddToScheme(scheme.Scheme)
+	if err != nil {
+		logger.Info("unable to add prometheus operator monitoring to scheme")
+		return nil, nil, err
+	}
+
+	return msvc, layers, nil
+}
+
+func generateManifests(ctx context.Context, manifestFile string, configFile string, parentFiles ...string) (*string, error) {
+	logger := log.FromContext(ctx)
+
+	msvc, layers, err := readInputs(ctx, manifestFile, configFile, parentFiles)
+	if err != nil {
 		return nil, err
 	}
 
-	// update child resources
-	cR := config.MergeChildResources(ctx, msvc, scheme.Scheme, &rbacOptions)
-	logger.V(1).Info("generateManifest", "baseResources", cR)
+	var (
+		baseConfigMap *corev1.ConfigMap
+		parents       []render.BaseConfigLayer
+	)
+	if n := len(layers); n > 0 {
+		baseConfigMap = layers[n-1].ConfigMap
+		parents = layers[:n-1]
+	}
+
+	// render child resources
+	cR, err := render.Render(ctx, msvc, baseConfigMap, render.Options{
+		Scheme:      scheme.Scheme,
+		RBACOptions: rbacOptions,
+		Parents:     parents,
+	})
+	if err != nil {
+		logger.Error(err, "unable to render child resources")
+		return nil, err
+	}
+	logger.V(1).Info("generateManifest", "childResources", cR)
 
 	yamlStr := ""
 	yamlBytes, err := yaml.Marshal(&cR)
@@ -128,8 +209,43 @@ func generateManifests(ctx context.Context, manifestFile string, configFile stri
 	return &yamlStr, nil
 }
 
+// explainManifests reports, for every field of the child resources, the
+// layer that set it: the ModelService, the base config or one of its parents
+func explainManifests(ctx context.Context, manifestFile string, configFile string, parentFiles ...string) (*string, error) {
+	logger := log.FromContext(ctx)
+
+	msvc, layers, err := readInputs(ctx, manifestFile, configFile, parentFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := render.Explain(ctx, msvc, layers, render.Options{
+		Scheme:      scheme.Scheme,
+		RBACOptions: rbacOptions,
+	})
+	if err != nil {
+		logger.Error(err, "unable to explain child resources")
+		return nil, err
+	}
+
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tSOURCE")
+	for _, field := range fields {
+		fmt.Fprintf(w, "%s\t%s\n", field.Path, strings.Join(field.Sources, ", "))
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	result := out.String()
+	return &result, nil
+}
+
 var modelServiceManifest string
 var baseConfigurationManifest string
+var parentBaseConfigManifests []string
+var explain bool
 
 var generateCmd = &cobra.Command{
 	Use:   "generate",
@@ -147,7 +263,19 @@ var generateCmd = &cobra.Command{
 		log.SetLogger(logger)
 		log.IntoContext(ctx, logger)
 
-		result, err := generateManifests(ctx, modelServiceManifest, baseConfigurationManifest)
+		generate := generateManifests
+		if explain {
+			generate = explainManifests
+		}
+		result, err := generate(ctx, modelServiceManifest, baseConfigurationManifest, parentBaseConfigManifests...)
+		var decodeErrs render.DecodeErrors
+		if errors.As(err, &decodeErrs) {
+			// one line per invalid key is easier to read than the joined error
+			for _, decodeErr := range decodeErrs {
+				fmt.Fprintln(cmd.ErrOrStderr(), decodeErr)
+			}
+			return fmt.Errorf("%s: %d base config keys failed to decode", baseConfigurationManifest, len(decodeErrs))
+		}
 		if err != nil {
 			return err
 		}
@@ -161,5 +289,7 @@ func init() {
 	generateCmd.Flags().StringVarP(&modelServiceManifest, "modelservice", "m", "", "File containing the ModelService definition.")
 	_ = generateCmd.MarkFlagRequired("modelservice")
 	generateCmd.Flags().StringVarP(&baseConfigurationManifest, "baseconfig", "b", "", "File containing the base platform configuration.")
+	generateCmd.Flags().StringArrayVarP(&parentBaseConfigManifests, "parent", "p", nil, "File containing a parent of the base configuration. May be repeated.")
+	generateCmd.Flags().BoolVar(&explain, "explain", false, "Report the layer that set each field instead of the manifests.")
 	rootCmd.AddCommand(generateCmd)
 }
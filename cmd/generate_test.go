@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-model-service/pkg/render"
 )
 
 var _ = Describe("generate command", func() {
@@ -84,4 +87,52 @@ var _ = Describe("generate command", func() {
 			Expect(err).ToNot(BeNil())
 		})
 	})
+
+	Context("call with invalid baseConfiguration keys", func() {
+		modelServiceYaml := filepath.Join("..", "samples", "test", "msvc.yaml")
+		baseConfigYaml := filepath.Join("..", "test", "baseconfigs", "invalid-keys.yaml")
+		It("should report every decoding error", func() {
+			_, err := generateManifests(ctx, modelServiceYaml, baseConfigYaml)
+			var decodeErrs render.DecodeErrors
+			Expect(errors.As(err, &decodeErrs)).To(BeTrue())
+			Expect(decodeErrs).To(HaveLen(2))
+			Expect(decodeErrs[0].Key).To(Equal("decodeDeployment"))
+			Expect(decodeErrs[0].Line).To(Equal(3))
+			Expect(decodeErrs[1].Key).To(Equal("eppService"))
+			Expect(decodeErrs[1].Line).To(Equal(4))
+		})
+	})
+
+	Context("call with a ClusterModelServiceBaseConfig", func() {
+		modelServiceYaml := filepath.Join("..", "samples", "msvcs", "granite3.2.yaml")
+		baseConfigYaml := filepath.Join("..", "samples", "baseconfigs", "simple-clusterbaseconfig.yaml")
+		It("should generate manifests", func() {
+			manifests, err := generateManifests(ctx, modelServiceYaml, baseConfigYaml)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*manifests).To(ContainSubstring("image: vllm/vllm-openai:v0.8.5"))
+			Expect(*manifests).To(ContainSubstring("port: 8000"))
+		})
+	})
+
+	Context("call with a base config and its parent", func() {
+		modelServiceYaml := filepath.Join("..", "samples", "msvcs", "granite3.2.yaml")
+		baseConfigYaml := filepath.Join("..", "samples", "baseconfigs", "h100-baseconfig.yaml")
+		parentYaml := filepath.Join("..", "samples", "baseconfigs", "simple-baseconfig.yaml")
+		It("should merge the base config on top of its parent", func() {
+			manifests, err := generateManifests(ctx, modelServiceYaml, baseConfigYaml, parentYaml)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*manifests).To(ContainSubstring("image: vllm/vllm-openai:v0.8.5"))
+			Expect(*manifests).To(ContainSubstring("VLLM_ATTENTION_BACKEND"))
+		})
+		It("should report the layer that set each field", func() {
+			explained, err := explainManifests(ctx, modelServiceYaml, baseConfigYaml, parentYaml)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*explained).To(MatchRegexp(`containers\[vllm\]\.image\s+ConfigMap/.*simple-base-config`))
+			Expect(*explained).To(MatchRegexp(`nodeSelector\.nvidia\.com/gpu\.product\s+ConfigMap/.*h100-base-config`))
+		})
+		It("should report a missing parent", func() {
+			_, err := generateManifests(ctx, modelServiceYaml, baseConfigYaml)
+			Expect(err).To(MatchError(ContainSubstring(`ConfigMap "simple-base-config" not found`)))
+		})
+	})
 })
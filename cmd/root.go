@@ -3,12 +3,12 @@ package cmd
 import (
 	"os"
 
-	"github.com/llm-d/llm-d-model-service/internal/controller"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
 	"github.com/spf13/cobra"
 )
 
 // rbac options
-var rbacOptions controller.RBACOptions
+var rbacOptions render.RBACOptions
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"os"
@@ -26,6 +27,8 @@ import (
 
 	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
 	"github.com/llm-d/llm-d-model-service/internal/controller"
+	"github.com/llm-d/llm-d-model-service/internal/tracing"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"go.uber.org/zap/zapcore"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	giev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
@@ -41,6 +44,8 @@ var metricsAddr string
 var metricsCertPath, metricsCertName, metricsCertKey string
 var webhookCertPath, webhookCertName, webhookCertKey string
 var defaultsYAMLPath string
+var referencePolicy controller.ReferencePolicy
+var tracingOptions tracing.Options
 var enableLeaderElection bool
 var probeAddr string
 var secureMetrics bool
@@ -74,6 +79,17 @@ func init() {
 	runCmd.Flags().BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	runCmd.Flags().StringVar(&defaultsYAMLPath, "defaults-yaml-path", "", "The YAML file containing the controller defaults.")
+	runCmd.Flags().StringSliceVar(&referencePolicy.AllowedBaseConfigNamespaces, "allowed-base-config-namespaces", []string{},
+		"Namespaces from which every ModelService may read base configs. "+
+			"Other namespaces require a BaseConfigGrant.")
+	runCmd.Flags().StringVar(&tracingOptions.Exporter, "tracing-exporter", tracing.NoneExporter,
+		"The exporter of reconcile traces: none, otlp or stdout.")
+	runCmd.Flags().StringVar(&tracingOptions.Endpoint, "tracing-otlp-endpoint", "",
+		"The host:port of the OTLP gRPC collector. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.")
+	runCmd.Flags().BoolVar(&tracingOptions.Insecure, "tracing-otlp-insecure", false,
+		"If set, traces are sent to the OTLP collector without TLS.")
+	runCmd.Flags().Float64Var(&tracingOptions.SamplingRatio, "tracing-sampling-ratio", 1,
+		"The fraction of reconciles traced, between 0 and 1.")
 
 	rootCmd.AddCommand(runCmd)
 }
@@ -86,6 +102,7 @@ func runController() {
 	utilruntime.Must(msv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1.Install(scheme))
 	utilruntime.Must(giev1alpha2.Install(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	var opts = zap.Options{
 		Development: false,
 		TimeEncoder: zapcore.RFC3339NanoTimeEncoder,
@@ -208,18 +225,44 @@ func runController() {
 		os.Exit(1)
 	}
 
+	if err = controller.SetupIndexes(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to register ModelService indexes")
+		os.Exit(1)
+	}
+
 	// Step 1: Read in the modelServiceDefaults struct
 	// Pass that into Reconciler below
 
 	if err = (&controller.ModelServiceReconciler{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		RBACOptions: rbacOptions,
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		RBACOptions:     rbacOptions,
+		ReferencePolicy: referencePolicy,
+		Recorder:        mgr.GetEventRecorderFor("modelservice-controller"),
+		APIReader:       mgr.GetAPIReader(),
 		// Defaults: &modelServiceDefaults // from above
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ModelService")
 		os.Exit(1)
 	}
+	if err = (&controller.ModelServiceBaseConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ModelServiceBaseConfig")
+		os.Exit(1)
+	}
+	if err = (&controller.ClusterModelServiceBaseConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterModelServiceBaseConfig")
+		os.Exit(1)
+	}
+	if err = controller.RegisterModelServiceCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register ModelService metrics")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -247,11 +290,21 @@ func runController() {
 		os.Exit(1)
 	}
 
+	ctx := ctrl.SetupSignalHandler()
+	shutdownTracing, err := tracing.Setup(ctx, tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
 }
 
 // runCmd represents the base command when called without any subcommands
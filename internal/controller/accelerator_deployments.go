@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deleteStaleAcceleratorDeployments deletes the deployments of the accelerator
+// overrides of msvc that are no longer rendered, e.g. after an override was removed.
+// They are not garbage collected until msvc is deleted
+func deleteStaleAcceleratorDeployments(ctx context.Context, r *ModelServiceReconciler, msvc *msv1alpha1.ModelService, childResources *render.ChildResources) error {
+	desired := map[string]bool{}
+	for _, deployment := range append(childResources.PrefillAcceleratorDeployments, childResources.DecodeAcceleratorDeployments...) {
+		desired[deployment.Name] = true
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(msvc.Namespace), client.HasLabels{render.AcceleratorLabel}); err != nil {
+		return err
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if desired[deployment.Name] || !ownedBy(deployment, msvc) {
+			continue
+		}
+		log.FromContext(ctx).Info("deleting accelerator deployment", "deployment", deployment.Name)
+		if err := r.Delete(ctx, deployment); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownedBy reports whether msvc is an owner of obj
+func ownedBy(obj metav1.Object, msvc *msv1alpha1.ModelService) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.UID == msvc.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// acceleratorReplicas returns the ready, available and expected replicas of the
+// deployments of the accelerator overrides of a role, and the Progressing
+// condition of the first one that exceeded its progress deadline, if any
+func (r *ModelServiceReconciler) acceleratorReplicas(ctx context.Context, desired []*appsv1.Deployment) (ready, available, expected int32, stalled *appsv1.DeploymentCondition, err error) {
+	for _, d := range desired {
+		var deployment appsv1.Deployment
+		if err := r.Get(ctx, client.ObjectKeyFromObject(d), &deployment); err != nil {
+			if !errors.IsNotFound(err) {
+				return 0, 0, 0, nil, err
+			}
+			// not created yet, e.g. while the model is downloaded
+			if d.Spec.Replicas != nil {
+				expected += *d.Spec.Replicas
+			}
+			continue
+		}
+
+		ready += deployment.Status.ReadyReplicas
+		available += deployment.Status.AvailableReplicas
+		if deployment.Spec.Replicas != nil {
+			expected += *deployment.Spec.Replicas
+		}
+		for _, c := range deployment.Status.Conditions {
+			if stalled == nil && c.Type == appsv1.DeploymentProgressing && c.Reason == progressDeadlineExceededReason {
+				stalled = c.DeepCopy()
+				stalled.Message = fmt.Sprintf("deployment %s: %s", deployment.Name, c.Message)
+			}
+		}
+	}
+	return ready, available, expected, stalled, nil
+}
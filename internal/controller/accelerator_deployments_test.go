@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Accelerator deployments", func() {
+	var (
+		ctx  context.Context
+		msvc *msv1alpha1.ModelService
+	)
+
+	// acceleratorDeployment returns a deployment of an accelerator override owned by owner
+	acceleratorDeployment := func(name string, owner types.UID) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{render.AcceleratorLabel: "A100"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "llm-d.ai/v1alpha1", Kind: "ModelService", Name: "owner", UID: owner}},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		msvc = &msv1alpha1.ModelService{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"}}
+	})
+
+	It("should delete the deployments of removed overrides only", func() {
+		desired := acceleratorDeployment("owner-decode-a100", msvc.UID)
+		stale := acceleratorDeployment("owner-decode-l40s", msvc.UID)
+		other := acceleratorDeployment("other-decode-l40s", "other-uid")
+		c := newIndexedClient(desired, stale, other)
+		r := &ModelServiceReconciler{Client: c, Scheme: c.Scheme()}
+
+		childResources := &render.ChildResources{DecodeAcceleratorDeployments: []*appsv1.Deployment{desired}}
+		Expect(deleteStaleAcceleratorDeployments(ctx, r, msvc, childResources)).To(Succeed())
+
+		deployments := &appsv1.DeploymentList{}
+		Expect(c.List(ctx, deployments, client.InNamespace("default"))).To(Succeed())
+		var names []string
+		for _, deployment := range deployments.Items {
+			names = append(names, deployment.Name)
+		}
+		Expect(names).To(ConsistOf(desired.Name, other.Name))
+	})
+
+	It("should replace a role deployment created with a selector matching the accelerator pods", func() {
+		podLabels := map[string]string{"llm-d.ai/role": "decode"}
+		existing := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner-decode", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: podLabels}},
+		}
+		desired := existing.DeepCopy()
+		desired.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: render.AcceleratorLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+		}
+		c := newIndexedClient(existing)
+		r := &ModelServiceReconciler{Client: c, Scheme: c.Scheme()}
+
+		// the deployment is deleted first, and created again on the next reconcile
+		Expect(createOrUpdatePDDeployment(ctx, r, desired.DeepCopy(), false)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(desired), &appsv1.Deployment{})).To(MatchError(ContainSubstring("not found")))
+
+		Expect(createOrUpdatePDDeployment(ctx, r, desired.DeepCopy(), false)).To(Succeed())
+		created := &appsv1.Deployment{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(desired), created)).To(Succeed())
+		Expect(created.Spec.Selector).To(Equal(desired.Spec.Selector))
+
+		Expect(createOrUpdatePDDeployment(ctx, r, desired.DeepCopy(), false)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(desired), &appsv1.Deployment{})).To(Succeed())
+	})
+
+	It("should count the replicas of the accelerator deployments and report a stalled one", func() {
+		created := acceleratorDeployment("owner-decode-a100", msvc.UID)
+		created.Status = appsv1.DeploymentStatus{
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Reason:  progressDeadlineExceededReason,
+				Message: "ReplicaSet has timed out progressing.",
+			}},
+		}
+		pending := acceleratorDeployment("owner-decode-h100", msvc.UID)
+		c := newIndexedClient(created)
+		r := &ModelServiceReconciler{Client: c, Scheme: c.Scheme()}
+
+		ready, available, expected, stalled, err := r.acceleratorReplicas(ctx, []*appsv1.Deployment{created, pending})
+		Expect(err).ToNot(HaveOccurred())
+		Expect([]int32{ready, available, expected}).To(Equal([]int32{1, 1, 4}))
+		Expect(stalled).ToNot(BeNil())
+		Expect(stalled.Message).To(Equal("deployment owner-decode-a100: ReplicaSet has timed out progressing."))
+	})
+})
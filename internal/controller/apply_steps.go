@@ -0,0 +1,428 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// httpRouteAttachedCondition reports whether the HTTPRoute of a ModelService has been attached
+const httpRouteAttachedCondition = "HTTPRouteAttached"
+
+// Reasons of the HTTPRouteAttached condition
+const (
+	attachedReason            = "Attached"
+	waitingForEPPReason       = "WaitingForEPP"
+	waitingForEndpointsReason = "WaitingForEndpoints"
+	dependencyFailedReason    = "DependencyFailed"
+	applyFailedReason         = "ApplyFailed"
+)
+
+// Steps applying the child resources of a ModelService
+const (
+	rbacStep       = "rbac"
+	authStep       = "auth"
+	configMapsStep = "configmaps"
+	cacheStep      = "cache"
+	downloadStep   = "download"
+	verifyStep     = "verify"
+	workloadsStep  = "workloads"
+	eppStep        = "epp"
+	poolStep       = "pool"
+	modelStep      = "model"
+	routeStep      = "route"
+	monitoringStep = "monitoring"
+)
+
+// applyStep creates or updates a group of child resources once the steps it depends on are applied
+type applyStep struct {
+	name      string
+	dependsOn []string
+	// apply creates or updates the resources of the step
+	apply func(ctx context.Context) []error
+	// gate, if set, holds the step until it returns an empty reason
+	gate func(ctx context.Context) (reason, message string, err error)
+}
+
+// stepState is the outcome of an applyStep
+type stepState int
+
+const (
+	stepApplied stepState = iota
+	stepFailed
+	stepWaiting
+	stepBlocked
+)
+
+// stepOutcome records the state of an applyStep and the reason it was not applied
+type stepOutcome struct {
+	state   stepState
+	reason  string
+	message string
+}
+
+// sortSteps orders steps so that every step comes after the steps it depends on.
+// Independent steps keep their relative order
+func sortSteps(steps []applyStep) ([]applyStep, error) {
+	known := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		known[step.name] = true
+	}
+
+	sorted := make([]applyStep, 0, len(steps))
+	done := make(map[string]bool, len(steps))
+	for len(sorted) < len(steps) {
+		progressed := false
+		for _, step := range steps {
+			if done[step.name] {
+				continue
+			}
+			ready := true
+			for _, dep := range step.dependsOn {
+				if !known[dep] {
+					return nil, fmt.Errorf("step %q depends on unknown step %q", step.name, dep)
+				}
+				if !done[dep] {
+					ready = false
+				}
+			}
+			if ready {
+				sorted = append(sorted, step)
+				done[step.name] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			var pending []string
+			for _, step := range steps {
+				if !done[step.name] {
+					pending = append(pending, step.name)
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between steps %s", strings.Join(pending, ", "))
+		}
+	}
+	return sorted, nil
+}
+
+// runSteps applies steps in dependency order. A step is skipped if one of its
+// dependencies failed or is waiting; every error of the applied steps is returned
+func runSteps(ctx context.Context, steps []applyStep) (map[string]stepOutcome, []error) {
+	sorted, err := sortSteps(steps)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	outcomes := make(map[string]stepOutcome, len(sorted))
+	var errs []error
+	for _, step := range sorted {
+		var blockedBy []string
+		for _, dep := range step.dependsOn {
+			if outcomes[dep].state != stepApplied {
+				blockedBy = append(blockedBy, dep)
+			}
+		}
+		if len(blockedBy) > 0 {
+			log.FromContext(ctx).V(1).Info("skipping step until its dependencies are applied", "step", step.name, "dependencies", blockedBy)
+			outcomes[step.name] = stepOutcome{
+				state:   stepBlocked,
+				reason:  dependencyFailedReason,
+				message: fmt.Sprintf("waiting for %s", strings.Join(blockedBy, ", ")),
+			}
+			continue
+		}
+
+		if step.gate != nil {
+			reason, message, err := step.gate(ctx)
+			if err != nil {
+				errs = append(errs, err)
+				outcomes[step.name] = stepOutcome{state: stepFailed, reason: applyFailedReason, message: err.Error()}
+				continue
+			}
+			if reason != "" {
+				log.FromContext(ctx).V(1).Info("holding step", "step", step.name, "reason", reason, "message", message)
+				outcomes[step.name] = stepOutcome{state: stepWaiting, reason: reason, message: message}
+				continue
+			}
+		}
+
+		var stepErrs []error
+		for _, e := range step.apply(ctx) {
+			if e != nil {
+				stepErrs = append(stepErrs, e)
+			}
+		}
+		if len(stepErrs) > 0 {
+			errs = append(errs, stepErrs...)
+			outcomes[step.name] = stepOutcome{state: stepFailed, reason: applyFailedReason, message: fmt.Sprintf("%d resources could not be applied", len(stepErrs))}
+			continue
+		}
+		outcomes[step.name] = stepOutcome{state: stepApplied}
+	}
+	return outcomes, errs
+}
+
+// httpRouteGate holds the creation of the HTTPRoute until the EPP is available
+// and the InferencePool has ready endpoints. An existing HTTPRoute is always updated
+func (r *ModelServiceReconciler) httpRouteGate(ctx context.Context, childResources *render.ChildResources) (reason, message string, err error) {
+	route := childResources.HTTPRoute
+	err = r.Get(ctx, client.ObjectKey{Name: route.Name, Namespace: route.Namespace}, &gatewayv1.HTTPRoute{})
+	if err == nil {
+		return "", "", nil
+	}
+	if !errors.IsNotFound(err) {
+		return "", "", err
+	}
+
+	if childResources.ShouldCreateEPPDeployment() {
+		available, err := r.deploymentAvailable(ctx, childResources.EPPDeployment)
+		if err != nil {
+			return "", "", err
+		}
+		if !available {
+			return waitingForEPPReason, fmt.Sprintf("EPP deployment %s is not available", childResources.EPPDeployment.Name), nil
+		}
+	}
+
+	if childResources.ShouldCreateInferencePool() {
+		ready, err := r.poolHasReadyEndpoints(ctx, childResources)
+		if err != nil {
+			return "", "", err
+		}
+		if !ready {
+			return waitingForEndpointsReason, fmt.Sprintf("InferencePool %s has no ready endpoints", childResources.InferencePool.Name), nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// deploymentAvailable reports whether the Available condition of the deployment in the cluster is true
+func (r *ModelServiceReconciler) deploymentAvailable(ctx context.Context, desired *appsv1.Deployment) (bool, error) {
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, client.ObjectKey{Name: desired.Name, Namespace: desired.Namespace}, &deployment); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	for _, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentAvailable {
+			return c.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+// poolHasReadyEndpoints reports whether a prefill or decode deployment selected
+// by the InferencePool has ready replicas
+func (r *ModelServiceReconciler) poolHasReadyEndpoints(ctx context.Context, childResources *render.ChildResources) (bool, error) {
+	selectorLabels := labels.Set{}
+	for key, value := range childResources.InferencePool.Spec.Selector {
+		selectorLabels[string(key)] = string(value)
+	}
+	selector := labels.SelectorFromSet(selectorLabels)
+
+	for _, desired := range childResources.PDDeployments() {
+		if !selector.Matches(labels.Set(desired.Spec.Template.Labels)) {
+			continue
+		}
+		var deployment appsv1.Deployment
+		if err := r.Get(ctx, client.ObjectKey{Name: desired.Name, Namespace: desired.Namespace}, &deployment); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if deployment.Status.ReadyReplicas > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newHTTPRouteCondition returns the HTTPRouteAttached condition from the outcome of the route step
+func newHTTPRouteCondition(outcome stepOutcome) metav1.Condition {
+	if outcome.state == stepApplied {
+		return metav1.Condition{
+			Type:    httpRouteAttachedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  attachedReason,
+			Message: "HTTPRoute is attached",
+		}
+	}
+	return metav1.Condition{
+		Type:    httpRouteAttachedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  outcome.reason,
+		Message: outcome.message,
+	}
+}
+
+// modelServiceSteps returns the steps applying childResources: RBAC, ConfigMaps and,
+// once the authentication Secret is ready, the model cache, then the prefill and decode
+// workloads once the model is downloaded and its image digest verified, the EPP, the InferencePool, the InferenceModel
+// and finally the HTTPRoute
+func (r *ModelServiceReconciler) modelServiceSteps(childResources *render.ChildResources, msvc *msv1alpha1.ModelService) []applyStep {
+	return []applyStep{
+		{
+			name: rbacStep,
+			apply: func(ctx context.Context) []error {
+				var errs []error
+				if childResources.ShouldCreatePDServiceAccount() {
+					errs = append(errs, createOrUpdateServiceAccount(ctx, r, childResources.PDServiceAccount))
+				}
+				if childResources.ShouldCreateEPPServiceAccount() {
+					errs = append(errs, createOrUpdateServiceAccount(ctx, r, childResources.EPPServiceAccount))
+				}
+				if childResources.ShouldCreateEPPRoleBinding() {
+					errs = append(errs, createOrUpdateRoleBinding(ctx, r, childResources.EPPRoleBinding))
+				}
+				return errs
+			},
+		},
+		{
+			name: configMapsStep,
+			apply: func(ctx context.Context) []error {
+				if !childResources.ShouldCreateConfigMaps() {
+					return nil
+				}
+				return createOrUpdateConfigMaps(ctx, r, childResources.ConfigMaps)
+			},
+		},
+		{
+			name: authStep,
+			gate: func(ctx context.Context) (string, string, error) {
+				secretName, keys, ok := render.AuthSecretKeys(msvc)
+				if !ok {
+					return "", "", nil
+				}
+				return r.authSecretGate(ctx, msvc.Namespace, secretName, keys)
+			},
+			apply: func(context.Context) []error { return nil },
+		},
+		{
+			name:      cacheStep,
+			dependsOn: []string{authStep},
+			apply: func(ctx context.Context) []error {
+				if !childResources.ShouldCreateModelCache() {
+					return nil
+				}
+				return []error{
+					createOrUpdatePersistentVolumeClaim(ctx, r, childResources.ModelCachePVC),
+					applyModelDownloadJob(ctx, r, childResources.ModelDownloadJob),
+				}
+			},
+		},
+		{
+			name:      downloadStep,
+			dependsOn: []string{cacheStep},
+			// the Job is watched, so the workloads are applied on a later reconcile
+			gate: func(ctx context.Context) (string, string, error) {
+				if !childResources.ShouldCreateModelCache() {
+					return "", "", nil
+				}
+				return r.modelDownloadGate(ctx, childResources.ModelDownloadJob)
+			},
+			apply: func(context.Context) []error { return nil },
+		},
+		{
+			name: verifyStep,
+			gate: func(ctx context.Context) (string, string, error) {
+				return r.modelDigestGate(ctx, msvc)
+			},
+			apply: func(context.Context) []error { return nil },
+		},
+		{
+			name:      workloadsStep,
+			dependsOn: []string{rbacStep, configMapsStep, authStep, downloadStep, verifyStep},
+			apply: func(ctx context.Context) []error {
+				var errs []error
+				if childResources.ShouldCreatePrefillDeployment() {
+					errs = append(errs, createOrUpdatePDDeployment(ctx, r, childResources.PrefillDeployment, msvc.Spec.DecoupleScaling))
+				}
+				if childResources.ShouldCreatePrefillService() {
+					errs = append(errs, createOrUpdateService(ctx, r, childResources.PrefillService))
+				}
+				if childResources.ShouldCreateDecodeDeployment() {
+					errs = append(errs, createOrUpdatePDDeployment(ctx, r, childResources.DecodeDeployment, msvc.Spec.DecoupleScaling))
+				}
+				if childResources.ShouldCreateDecodeService() {
+					errs = append(errs, createOrUpdateService(ctx, r, childResources.DecodeService))
+				}
+				for _, deployment := range append(childResources.PrefillAcceleratorDeployments, childResources.DecodeAcceleratorDeployments...) {
+					errs = append(errs, createOrUpdatePDDeployment(ctx, r, deployment, msvc.Spec.DecoupleScaling))
+				}
+				return append(errs, deleteStaleAcceleratorDeployments(ctx, r, msvc, childResources))
+			},
+		},
+		{
+			name:      eppStep,
+			dependsOn: []string{rbacStep, configMapsStep},
+			apply: func(ctx context.Context) []error {
+				var errs []error
+				if childResources.ShouldCreateEPPDeployment() {
+					errs = append(errs, createOrUpdateDeployment(ctx, r, childResources.EPPDeployment))
+				}
+				if childResources.ShouldCreateEPPService() {
+					errs = append(errs, createOrUpdateService(ctx, r, childResources.EPPService))
+				}
+				return errs
+			},
+		},
+		{
+			name:      poolStep,
+			dependsOn: []string{workloadsStep, eppStep},
+			apply: func(ctx context.Context) []error {
+				if !childResources.ShouldCreateInferencePool() {
+					return nil
+				}
+				return []error{createOrUpdateInferencePool(ctx, r, childResources.InferencePool)}
+			},
+		},
+		{
+			name:      modelStep,
+			dependsOn: []string{poolStep},
+			apply: func(ctx context.Context) []error {
+				if !childResources.ShouldCreateInferenceModel() {
+					return nil
+				}
+				return []error{createOrUpdateInferenceModel(ctx, r, childResources.InferenceModel)}
+			},
+		},
+		{
+			name:      routeStep,
+			dependsOn: []string{poolStep, modelStep},
+			gate: func(ctx context.Context) (string, string, error) {
+				if !childResources.ShouldCreateHTTPRoute() {
+					return "", "", nil
+				}
+				return r.httpRouteGate(ctx, childResources)
+			},
+			apply: func(ctx context.Context) []error {
+				if !childResources.ShouldCreateHTTPRoute() {
+					return nil
+				}
+				return []error{createOrUpdateHTTPRoute(ctx, r, childResources.HTTPRoute)}
+			},
+		},
+		{
+			name:      monitoringStep,
+			dependsOn: []string{workloadsStep},
+			apply: func(ctx context.Context) []error {
+				if !childResources.ShouldCreatePodMonitor() {
+					return nil
+				}
+				return []error{createOrUpdatePodMonitor(ctx, r, childResources.PodMonitor)}
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Apply steps", func() {
+	var applied []string
+
+	step := func(name string, err error, dependsOn ...string) applyStep {
+		return applyStep{
+			name:      name,
+			dependsOn: dependsOn,
+			apply: func(context.Context) []error {
+				applied = append(applied, name)
+				return []error{nil, err}
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		applied = nil
+	})
+
+	It("should apply steps after their dependencies", func() {
+		outcomes, errs := runSteps(context.Background(), []applyStep{
+			step(routeStep, nil, poolStep),
+			step(poolStep, nil, workloadsStep, eppStep),
+			step(eppStep, nil, rbacStep),
+			step(workloadsStep, nil, rbacStep),
+			step(rbacStep, nil),
+		})
+		Expect(errs).To(BeEmpty())
+		Expect(applied).To(Equal([]string{rbacStep, eppStep, workloadsStep, poolStep, routeStep}))
+		Expect(outcomes[routeStep].state).To(Equal(stepApplied))
+	})
+
+	It("should report every error and skip the dependents of failed steps", func() {
+		outcomes, errs := runSteps(context.Background(), []applyStep{
+			step(workloadsStep, fmt.Errorf("decode deployment failed")),
+			step(eppStep, fmt.Errorf("epp deployment failed")),
+			step(poolStep, nil, workloadsStep, eppStep),
+			step(monitoringStep, nil),
+		})
+		Expect(errs).To(HaveLen(2))
+		Expect(applied).To(Equal([]string{workloadsStep, eppStep, monitoringStep}))
+		Expect(outcomes[poolStep]).To(Equal(stepOutcome{
+			state:   stepBlocked,
+			reason:  dependencyFailedReason,
+			message: "waiting for workloads, epp",
+		}))
+	})
+
+	It("should hold a gated step and its dependents", func() {
+		gated := step(routeStep, nil)
+		gated.gate = func(context.Context) (string, string, error) {
+			return waitingForEPPReason, "EPP deployment is not available", nil
+		}
+		outcomes, errs := runSteps(context.Background(), []applyStep{gated, step(monitoringStep, nil, routeStep)})
+		Expect(errs).To(BeEmpty())
+		Expect(applied).To(BeEmpty())
+		Expect(newHTTPRouteCondition(outcomes[routeStep]).Reason).To(Equal(waitingForEPPReason))
+		Expect(outcomes[monitoringStep].state).To(Equal(stepBlocked))
+	})
+
+	It("should refuse dependency cycles", func() {
+		_, err := sortSteps([]applyStep{step(poolStep, nil, modelStep), step(modelStep, nil, poolStep)})
+		Expect(err).To(MatchError(ContainSubstring("dependency cycle between steps pool, model")))
+	})
+})
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// authSecretReadyCondition reports whether modelArtifacts.authSecretName exists with the keys it must provide
+const authSecretReadyCondition = "AuthSecretReady"
+
+// Reasons of the AuthSecretReady condition
+const (
+	secretFoundReason      = "SecretFound"
+	secretNotFoundReason   = "SecretNotFound"
+	secretKeyMissingReason = "SecretKeyMissing"
+)
+
+// authSecretPollInterval is the delay before checking a missing or incomplete
+// authentication Secret again; Secrets are not watched
+const authSecretPollInterval = 30 * time.Second
+
+// authSecretGate holds the model download and the workloads until the Secret
+// secretName exists with every key of keys, so that their pods do not fail
+// with CreateContainerConfigError
+func (r *ModelServiceReconciler) authSecretGate(ctx context.Context, namespace, secretName string, keys []string) (reason, message string, err error) {
+	var secret corev1.Secret
+	if err := r.secretReader().Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", "", err
+		}
+		return secretNotFoundReason, fmt.Sprintf("Secret %s not found", secretName), nil
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if _, ok := secret.Data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return secretKeyMissingReason, fmt.Sprintf("Secret %s has no key %s", secretName, strings.Join(missing, ", ")), nil
+	}
+	return "", "", nil
+}
+
+// newAuthSecretCondition returns the AuthSecretReady condition from the outcome of the auth step
+func newAuthSecretCondition(outcome stepOutcome, secretName string) metav1.Condition {
+	if outcome.state == stepApplied {
+		return metav1.Condition{
+			Type:    authSecretReadyCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  secretFoundReason,
+			Message: fmt.Sprintf("Secret %s is ready", secretName),
+		}
+	}
+	return metav1.Condition{
+		Type:    authSecretReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  outcome.reason,
+		Message: outcome.message,
+	}
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+)
+
+// ModelServiceBaseConfigReconciler keeps the status of ModelServiceBaseConfig
+// objects up to date with the ModelServices referencing them
+type ModelServiceBaseConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=llm-d.ai,resources=modelservicebaseconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=llm-d.ai,resources=modelservicebaseconfigs/status,verbs=get;update;patch
+
+func (r *ModelServiceBaseConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	baseConfig := &msv1alpha1.ModelServiceBaseConfig{}
+	if err := r.Get(ctx, req.NamespacedName, baseConfig); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status, err := baseConfigStatus(ctx, r.Client, msv1alpha1.ModelServiceBaseConfigKind, baseConfig.Namespace, baseConfig.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if equality.Semantic.DeepEqual(&baseConfig.Status, status) {
+		return ctrl.Result{}, nil
+	}
+
+	log.FromContext(ctx).V(1).Info("updating base config status", "modelServices", status.ModelServiceCount)
+	baseConfig.Status = *status
+	return ctrl.Result{}, client.IgnoreNotFound(r.Status().Update(ctx, baseConfig))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ModelServiceBaseConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&msv1alpha1.ModelServiceBaseConfig{}).
+		Named("modelservicebaseconfig").
+		Watches(&msv1alpha1.ModelService{}, handler.EnqueueRequestsFromMapFunc(baseConfigRefMapFunc(msv1alpha1.ModelServiceBaseConfigKind))).
+		Complete(r)
+}
+
+// ClusterModelServiceBaseConfigReconciler keeps the status of
+// ClusterModelServiceBaseConfig objects up to date with the ModelServices referencing them
+type ClusterModelServiceBaseConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=llm-d.ai,resources=clustermodelservicebaseconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=llm-d.ai,resources=clustermodelservicebaseconfigs/status,verbs=get;update;patch
+
+func (r *ClusterModelServiceBaseConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	baseConfig := &msv1alpha1.ClusterModelServiceBaseConfig{}
+	if err := r.Get(ctx, req.NamespacedName, baseConfig); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status, err := baseConfigStatus(ctx, r.Client, msv1alpha1.ClusterModelServiceBaseConfigKind, "", baseConfig.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if equality.Semantic.DeepEqual(&baseConfig.Status, status) {
+		return ctrl.Result{}, nil
+	}
+
+	log.FromContext(ctx).V(1).Info("updating cluster base config status", "modelServices", status.ModelServiceCount)
+	baseConfig.Status = *status
+	return ctrl.Result{}, client.IgnoreNotFound(r.Status().Update(ctx, baseConfig))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterModelServiceBaseConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&msv1alpha1.ClusterModelServiceBaseConfig{}).
+		Named("clustermodelservicebaseconfig").
+		Watches(&msv1alpha1.ModelService{}, handler.EnqueueRequestsFromMapFunc(baseConfigRefMapFunc(msv1alpha1.ClusterModelServiceBaseConfigKind))).
+		Complete(r)
+}
+
+// baseConfigRef returns the namespaced name of the base config of the given
+// kind referenced by msvc. The namespace is empty for cluster-scoped kinds
+func baseConfigRef(msvc *msv1alpha1.ModelService, kind string) (types.NamespacedName, bool) {
+	ref := msvc.Spec.BaseConfigMapRef
+	if ref == nil {
+		return types.NamespacedName{}, false
+	}
+	refKind := ref.Kind
+	if refKind == "" {
+		refKind = msv1alpha1.ConfigMapKind
+	}
+	if refKind != kind {
+		return types.NamespacedName{}, false
+	}
+	if kind == msv1alpha1.ClusterModelServiceBaseConfigKind {
+		return types.NamespacedName{Name: ref.Name}, true
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = msvc.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}, true
+}
+
+// baseConfigRefMapFunc maps a ModelService to the base config of the given kind it references.
+// Update events map both the old and the new ModelService, so a base config
+// is also requeued when a ModelService stops referencing it
+func baseConfigRefMapFunc(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		msvc, ok := obj.(*msv1alpha1.ModelService)
+		if !ok {
+			return nil
+		}
+		if name, ok := baseConfigRef(msvc, kind); ok {
+			return []reconcile.Request{{NamespacedName: name}}
+		}
+		return nil
+	}
+}
+
+// baseConfigRefIndex indexes ModelServices by the base config they reference
+const baseConfigRefIndex = "spec.baseConfigMapRef"
+
+// baseConfigRefKey returns the baseConfigRefIndex value of the base config
+// of the given kind, namespace and name
+func baseConfigRefKey(kind string, target types.NamespacedName) string {
+	return kind + "/" + target.String()
+}
+
+// indexBaseConfigRef returns the baseConfigRefIndex value of a ModelService
+func indexBaseConfigRef(obj client.Object) []string {
+	msvc, ok := obj.(*msv1alpha1.ModelService)
+	if !ok || msvc.Spec.BaseConfigMapRef == nil {
+		return nil
+	}
+	kind := msvc.Spec.BaseConfigMapRef.Kind
+	if kind == "" {
+		kind = msv1alpha1.ConfigMapKind
+	}
+	target, ok := baseConfigRef(msvc, kind)
+	if !ok {
+		return nil
+	}
+	return []string{baseConfigRefKey(kind, target)}
+}
+
+// baseConfigChainIndex indexes ModelServices by every base config of their
+// inheritance chain: the base config they reference and its parents
+const baseConfigChainIndex = "status.baseConfigChain"
+
+// indexBaseConfigChain returns the baseConfigChainIndex values of a ModelService:
+// the layers of status.baseConfigChain, and the base config it references,
+// which is not in the status until the ModelService is reconciled
+func indexBaseConfigChain(obj client.Object) []string {
+	msvc, ok := obj.(*msv1alpha1.ModelService)
+	if !ok {
+		return nil
+	}
+	keys := indexBaseConfigRef(msvc)
+	for _, layer := range msvc.Status.BaseConfigChain {
+		key := baseConfigRefKey(layer.Kind, types.NamespacedName{Namespace: layer.Namespace, Name: layer.Name})
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// modelServicesIndexedBy returns the ModelServices whose index value includes the
+// base config of the given kind, namespace and name. index is baseConfigRefIndex
+// or baseConfigChainIndex, and c must read from a cache with that index
+func modelServicesIndexedBy(ctx context.Context, c client.Reader, index, kind string, target types.NamespacedName) ([]msv1alpha1.ModelService, error) {
+	modelServices := &msv1alpha1.ModelServiceList{}
+	if err := c.List(ctx, modelServices, client.MatchingFields{index: baseConfigRefKey(kind, target)}); err != nil {
+		return nil, err
+	}
+	return modelServices.Items, nil
+}
+
+// baseConfigStatus lists the ModelServices referencing the base config
+// of the given kind, namespace and name. c must read from a cache with baseConfigRefIndex
+func baseConfigStatus(ctx context.Context, c client.Reader, kind, namespace, name string) (*msv1alpha1.BaseConfigStatus, error) {
+	modelServices, err := modelServicesIndexedBy(ctx, c, baseConfigRefIndex, kind, types.NamespacedName{Namespace: namespace, Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	status := &msv1alpha1.BaseConfigStatus{}
+	for _, msvc := range modelServices {
+		if !msvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		status.ModelServices = append(status.ModelServices, msv1alpha1.ModelServiceReference{
+			Namespace: msvc.Namespace,
+			Name:      msvc.Name,
+		})
+	}
+	sort.Slice(status.ModelServices, func(i, j int) bool {
+		if status.ModelServices[i].Namespace != status.ModelServices[j].Namespace {
+			return status.ModelServices[i].Namespace < status.ModelServices[j].Namespace
+		}
+		return status.ModelServices[i].Name < status.ModelServices[j].Name
+	})
+	status.ModelServiceCount = int32(len(status.ModelServices))
+
+	return status, nil
+}
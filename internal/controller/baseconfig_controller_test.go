@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// newIndexedClient returns a fake client holding objs, with the indexes registered by
+// SetupIndexes: the envtest client reads from the API server, which has no such index
+func newIndexedClient(objs ...client.Object) client.Client {
+	builder := fake.NewClientBuilder().
+		WithScheme(k8sClient.Scheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&msv1alpha1.ModelService{}, &msv1alpha1.ModelServiceBaseConfig{}, &msv1alpha1.ClusterModelServiceBaseConfig{})
+	for _, index := range fieldIndexes {
+		builder = builder.WithIndex(&msv1alpha1.ModelService{}, index.field, index.extract)
+	}
+	return builder.Build()
+}
+
+var _ = Describe("Base config CRDs", func() {
+	var (
+		ctx  context.Context
+		msvc *msv1alpha1.ModelService
+	)
+
+	newModelService := func(name string, ref *corev1.ObjectReference) *msv1alpha1.ModelService {
+		return &msv1alpha1.ModelService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: msv1alpha1.ModelServiceSpec{
+				BaseConfigMapRef: ref,
+				Routing:          msv1alpha1.Routing{ModelName: "model"},
+				ModelArtifacts:   msv1alpha1.ModelArtifacts{URI: "hf://org/model"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, msvc))).To(Succeed())
+	})
+
+	It("should read a ModelServiceBaseConfig and list its ModelServices", func() {
+		baseConfig := &msv1alpha1.ModelServiceBaseConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "namespaced-base-config", Namespace: "default"},
+			Spec: msv1alpha1.BaseConfigSpec{
+				DecodeDeployment: &runtime.RawExtension{
+					Raw: []byte(`{"spec":{"replicas":3}}`),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, baseConfig)).To(Succeed())
+		defer func() { Expect(k8sClient.Delete(ctx, baseConfig)).To(Succeed()) }()
+
+		msvc = newModelService("uses-namespaced-base-config", &corev1.ObjectReference{
+			Kind: msv1alpha1.ModelServiceBaseConfigKind,
+			Name: baseConfig.Name,
+		})
+		Expect(k8sClient.Create(ctx, msvc)).To(Succeed())
+
+		By("converting the base config for the ModelService reconciler")
+		reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bc.DecodeDeployment).ToNot(BeNil())
+		Expect(*bc.DecodeDeployment.Spec.Replicas).To(Equal(int32(3)))
+
+		By("listing the ModelService in the base config status")
+		indexedClient := newIndexedClient(baseConfig, msvc)
+		baseConfigReconciler := &ModelServiceBaseConfigReconciler{Client: indexedClient, Scheme: k8sClient.Scheme()}
+		_, err = baseConfigReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(baseConfig)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(indexedClient.Get(ctx, client.ObjectKeyFromObject(baseConfig), baseConfig)).To(Succeed())
+		Expect(baseConfig.Status.ModelServiceCount).To(Equal(int32(1)))
+		Expect(baseConfig.Status.ModelServices).To(ConsistOf(msv1alpha1.ModelServiceReference{
+			Namespace: msvc.Namespace,
+			Name:      msvc.Name,
+		}))
+	})
+
+	It("should read a ClusterModelServiceBaseConfig from any namespace", func() {
+		baseConfig := &msv1alpha1.ClusterModelServiceBaseConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-base-config"},
+			Spec: msv1alpha1.BaseConfigSpec{
+				PDServiceAccount: &corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "platform"}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, baseConfig)).To(Succeed())
+		defer func() { Expect(k8sClient.Delete(ctx, baseConfig)).To(Succeed()) }()
+
+		msvc = newModelService("uses-cluster-base-config", &corev1.ObjectReference{
+			Kind:      msv1alpha1.ClusterModelServiceBaseConfigKind,
+			Name:      baseConfig.Name,
+			Namespace: "ignored",
+		})
+		Expect(k8sClient.Create(ctx, msvc)).To(Succeed())
+
+		reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bc.PDServiceAccount).ToNot(BeNil())
+		Expect(bc.PDServiceAccount.Labels).To(HaveKeyWithValue("team", "platform"))
+
+		indexedClient := newIndexedClient(baseConfig, msvc)
+		baseConfigReconciler := &ClusterModelServiceBaseConfigReconciler{Client: indexedClient, Scheme: k8sClient.Scheme()}
+		_, err = baseConfigReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: baseConfig.Name}})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(indexedClient.Get(ctx, client.ObjectKeyFromObject(baseConfig), baseConfig)).To(Succeed())
+		Expect(baseConfig.Status.ModelServiceCount).To(Equal(int32(1)))
+	})
+
+	It("should merge a ModelServiceBaseConfig on top of its parent ConfigMap", func() {
+		parent := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent-base-config", Namespace: "default"},
+			Data: map[string]string{
+				"decodeDeployment": "spec:\n  replicas: 2\n  minReadySeconds: 5\n",
+			},
+		}
+		Expect(k8sClient.Create(ctx, parent)).To(Succeed())
+		defer func() { Expect(k8sClient.Delete(ctx, parent)).To(Succeed()) }()
+
+		baseConfig := &msv1alpha1.ModelServiceBaseConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "child-base-config", Namespace: "default"},
+			Spec: msv1alpha1.BaseConfigSpec{
+				Parent: &corev1.ObjectReference{Name: parent.Name},
+				DecodeDeployment: &runtime.RawExtension{
+					Raw: []byte(`{"spec":{"replicas":4}}`),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, baseConfig)).To(Succeed())
+		defer func() { Expect(k8sClient.Delete(ctx, baseConfig)).To(Succeed()) }()
+
+		msvc = newModelService("uses-child-base-config", &corev1.ObjectReference{
+			Kind: msv1alpha1.ModelServiceBaseConfigKind,
+			Name: baseConfig.Name,
+		})
+		reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*bc.DecodeDeployment.Spec.Replicas).To(Equal(int32(4)))
+		Expect(bc.DecodeDeployment.Spec.MinReadySeconds).To(Equal(int32(5)))
+	})
+
+	It("should map a missing or edited parent to the ModelServices inheriting from it", func() {
+		parent := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "late-parent-base-config", Namespace: "default"},
+			Data:       map[string]string{"decodeDeployment": "spec:\n  replicas: 2\n"},
+		}
+		baseConfig := &msv1alpha1.ModelServiceBaseConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "inheriting-base-config", Namespace: "default"},
+			Spec:       msv1alpha1.BaseConfigSpec{Parent: &corev1.ObjectReference{Name: parent.Name}},
+		}
+		msvc = newModelService("inherits-late-parent", &corev1.ObjectReference{
+			Kind: msv1alpha1.ModelServiceBaseConfigKind,
+			Name: baseConfig.Name,
+		})
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(msvc)}
+		indexedClient := newIndexedClient(baseConfig, msvc)
+		reconciler := &ModelServiceReconciler{Client: indexedClient, Scheme: k8sClient.Scheme()}
+
+		By("recording the missing parent in the base config chain")
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(indexedClient.Get(ctx, request.NamespacedName, msvc)).To(Succeed())
+		Expect(msvc.Status.BaseConfigChain).To(Equal([]msv1alpha1.BaseConfigLayerReference{
+			{Kind: msv1alpha1.ConfigMapKind, Namespace: "default", Name: parent.Name},
+			{Kind: msv1alpha1.ModelServiceBaseConfigKind, Namespace: "default", Name: baseConfig.Name},
+		}))
+
+		By("mapping the creation of the parent to the ModelService")
+		Expect(indexedClient.Create(ctx, parent)).To(Succeed())
+		Expect(reconciler.configMapMapFunc(ctx, parent)).To(ConsistOf(request))
+
+		By("mapping an edit of the parent to the ModelService")
+		parent.Data["decodeDeployment"] = "spec:\n  replicas: 5\n"
+		Expect(indexedClient.Update(ctx, parent)).To(Succeed())
+		Expect(reconciler.configMapMapFunc(ctx, parent)).To(ConsistOf(request))
+		Expect(reconciler.baseConfigMapFunc(ctx, baseConfig)).To(ConsistOf(request))
+	})
+
+	It("should reject an unknown base config kind", func() {
+		msvc = newModelService("uses-unknown-kind", &corev1.ObjectReference{Kind: "Secret", Name: "base"})
+		reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		_, _, err := reconciler.getBaseConfigChain(ctx, msvc)
+		Expect(err).To(MatchError(ContainSubstring(`unsupported base config kind "Secret"`)))
+	})
+})
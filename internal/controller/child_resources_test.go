@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +16,15 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// loadBaseConfig resolves the base config chain of msvc and merges it, as Reconcile does
+func loadBaseConfig(ctx context.Context, reconciler *ModelServiceReconciler, msvc *msv1alpha1.ModelService) (*render.BaseConfig, error) {
+	chain, _, err := reconciler.getBaseConfigChain(ctx, msvc)
+	if err != nil {
+		return nil, err
+	}
+	return render.LoadBaseConfigLayers(ctx, chain, msvc)
+}
+
 // tests to check if base config reading works ok
 var _ = Describe("BaseConfig reader", func() {
 	var (
@@ -77,7 +87,7 @@ var _ = Describe("BaseConfig reader", func() {
 	})
 
 	It("should correctly deserialize the eppDeployment from ConfigMap", func() {
-		bc, err := reconciler.getChildResourcesFromConfigMap(ctx, msvc)
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
 		Expect(err).To(BeNil())
 		Expect(bc).ToNot(BeNil())
 		Expect(bc.EPPDeployment).ToNot(BeNil())
@@ -87,7 +97,7 @@ var _ = Describe("BaseConfig reader", func() {
 
 	It("should continue to correctly deserialize the eppDeployment from ConfigMap with pvc prefix", func() {
 		msvc.Spec.ModelArtifacts.URI = "pvc://my-pvc/path/to/opt-125m"
-		bc, err := reconciler.getChildResourcesFromConfigMap(ctx, msvc)
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
 		Expect(err).To(BeNil())
 		Expect(bc).ToNot(BeNil())
 		Expect(bc.EPPDeployment).ToNot(BeNil())
@@ -97,7 +107,7 @@ var _ = Describe("BaseConfig reader", func() {
 
 	It("should return nil if configmap ref is missing", func() {
 		msvc.Spec.BaseConfigMapRef = nil
-		bc, err := reconciler.getChildResourcesFromConfigMap(ctx, msvc)
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
 		Expect(err).To(BeNil())
 		Expect(bc.PrefillDeployment).To(BeNil())
 		Expect(bc.DecodeDeployment).To(BeNil())
@@ -111,7 +121,7 @@ var _ = Describe("BaseConfig reader", func() {
 
 	It("should error if the ConfigMap is missing", func() {
 		msvc.Spec.BaseConfigMapRef.Name = "doesnotexist"
-		bc, err := reconciler.getChildResourcesFromConfigMap(ctx, msvc)
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
 		Expect(err).To(HaveOccurred())
 		Expect(bc).To(BeNil())
 	})
@@ -207,7 +217,7 @@ spec:
 	})
 
 	It("should correctly interpolate container args", func() {
-		bc, err := reconciler.getChildResourcesFromConfigMap(ctx, msvc)
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
 		Expect(err).To(BeNil())
 		Expect(bc).ToNot(BeNil())
 		Expect(bc.PrefillDeployment).ToNot(BeNil())
@@ -218,7 +228,7 @@ spec:
 	})
 
 	It("should correctly interpolate containerPort", func() {
-		bc, err := reconciler.getChildResourcesFromConfigMap(ctx, msvc)
+		bc, err := loadBaseConfig(ctx, reconciler, msvc)
 		Expect(err).To(BeNil())
 		Expect(bc).ToNot(BeNil())
 		Expect(bc.PrefillDeployment).ToNot(BeNil())
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldIndex is an index of ModelServices in the cache of the manager
+type fieldIndex struct {
+	field   string
+	extract client.IndexerFunc
+}
+
+// fieldIndexes are the indexes of ModelServices shared by the controllers
+var fieldIndexes = []fieldIndex{
+	// map base configs, including ConfigMaps, to the ModelServices referencing them
+	{field: baseConfigRefIndex, extract: indexBaseConfigRef},
+	// map every base config of an inheritance chain, including parents, to the ModelServices inheriting from it
+	{field: baseConfigChainIndex, extract: indexBaseConfigChain},
+	// map PersistentVolumeClaims to the ModelServices serving a pvc:// model from them
+	{field: modelClaimIndex, extract: indexModelClaim},
+}
+
+// SetupIndexes registers the indexes of ModelServices used by the controllers.
+// It must be called once, before the controllers are set up
+func SetupIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	for _, index := range fieldIndexes {
+		if err := mgr.GetFieldIndexer().IndexField(ctx, &msv1alpha1.ModelService{}, index.field, index.extract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// metricsNamespace prefixes every metric of the ModelService controller
+const metricsNamespace = "llmd_modelservice"
+
+// readiness states of a ModelService, as reported by the modelservices metric
+const (
+	readyState    = "Ready"
+	notReadyState = "NotReady"
+	invalidState  = "Invalid"
+)
+
+// collectTimeout bounds the listing of ModelServices at scrape time
+const collectTimeout = 10 * time.Second
+
+var (
+	childResourceApplyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "child_resource_apply_failures_total",
+		Help:      "Number of child resources that could not be created or updated, by kind.",
+	}, []string{"kind"})
+
+	baseConfigErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "base_config_errors_total",
+		Help:      "Number of base configs that could not be resolved, decoded or rendered, by BaseConfigValid reason.",
+	}, []string{"reason"})
+
+	templateRenderDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "template_render_duration_seconds",
+		Help:      "Time taken to render the child resources of a ModelService from its base config.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+	})
+
+	modelServicesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "modelservices"),
+		"Number of ModelServices by readiness state and model artifact URI type.",
+		[]string{"state", "uri_type"}, nil,
+	)
+
+	readyReplicasDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "ready_replicas"),
+		"Ready replicas of a ModelService role, as reported by its status.",
+		[]string{"namespace", "modelservice", "role"}, nil,
+	)
+
+	desiredReplicasDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "desired_replicas"),
+		"Desired replicas of a ModelService role, as reported by its status.",
+		[]string{"namespace", "modelservice", "role"}, nil,
+	)
+
+	modelNameConflictsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "model_name_conflicts"),
+		"Number of ModelServices of a namespace serving the same model name, reported when more than one does.",
+		[]string{"namespace", "model_name"}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(childResourceApplyFailures, baseConfigErrors, templateRenderDuration)
+}
+
+// RegisterModelServiceCollector registers the metrics computed from the
+// ModelServices read by reader at scrape time, typically the cached client of the manager
+func RegisterModelServiceCollector(reader client.Reader) error {
+	return metrics.Registry.Register(&modelServiceCollector{reader: reader})
+}
+
+// modelServiceCollector reports the state of every ModelService of the cluster
+type modelServiceCollector struct {
+	reader client.Reader
+}
+
+// Describe implements prometheus.Collector
+func (c *modelServiceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- modelServicesDesc
+	ch <- readyReplicasDesc
+	ch <- desiredReplicasDesc
+	ch <- modelNameConflictsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *modelServiceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	var modelServices msv1alpha1.ModelServiceList
+	if err := c.reader.List(ctx, &modelServices); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ModelServices for metrics")
+		return
+	}
+
+	type stateKey struct{ state, uriType string }
+	type modelKey struct{ namespace, modelName string }
+	states := map[stateKey]int{}
+	models := map[modelKey]int{}
+
+	for i := range modelServices.Items {
+		msvc := &modelServices.Items[i]
+		states[stateKey{modelServiceState(msvc), string(render.UriType(msvc.Spec.ModelArtifacts.URI))}]++
+		models[modelKey{msvc.Namespace, msvc.Spec.Routing.ModelName}]++
+
+		for role, status := range roleReadiness(msvc) {
+			ready, desired, ok := parseReady(status)
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(readyReplicasDesc, prometheus.GaugeValue, float64(ready), msvc.Namespace, msvc.Name, role)
+			ch <- prometheus.MustNewConstMetric(desiredReplicasDesc, prometheus.GaugeValue, float64(desired), msvc.Namespace, msvc.Name, role)
+		}
+	}
+
+	for key, count := range states {
+		ch <- prometheus.MustNewConstMetric(modelServicesDesc, prometheus.GaugeValue, float64(count), key.state, key.uriType)
+	}
+	for key, count := range models {
+		if count > 1 {
+			ch <- prometheus.MustNewConstMetric(modelNameConflictsDesc, prometheus.GaugeValue, float64(count), key.namespace, key.modelName)
+		}
+	}
+}
+
+// roleReadiness returns the READY status of each role deployed by msvc
+func roleReadiness(msvc *msv1alpha1.ModelService) map[string]string {
+	roles := map[string]string{}
+	if msvc.Spec.Prefill != nil {
+		roles[render.PREFILL_ROLE] = msvc.Status.PrefillReady
+	}
+	if msvc.Spec.Decode != nil {
+		roles[render.DECODE_ROLE] = msvc.Status.DecodeReady
+	}
+	if msvc.Status.EppDeploymentRef != nil {
+		roles[render.EPP_ROLE] = msvc.Status.EppReady
+	}
+	return roles
+}
+
+// modelServiceState returns Invalid if the base config of msvc is not valid,
+// Ready if every role deployed by msvc has all its desired replicas ready,
+// and NotReady otherwise
+func modelServiceState(msvc *msv1alpha1.ModelService) string {
+	if meta.IsStatusConditionPresentAndEqual(msvc.Status.Conditions, baseConfigValidCondition, metav1.ConditionFalse) {
+		return invalidState
+	}
+
+	roles := roleReadiness(msvc)
+	if len(roles) == 0 {
+		return notReadyState
+	}
+	for _, status := range roles {
+		ready, desired, ok := parseReady(status)
+		if !ok || ready < desired {
+			return notReadyState
+		}
+	}
+	return readyState
+}
+
+// parseReady parses a READY status such as "1/2"
+func parseReady(status string) (ready, desired int32, ok bool) {
+	if _, err := fmt.Sscanf(status, "%d/%d", &ready, &desired); err != nil {
+		return 0, 0, false
+	}
+	return ready, desired, true
+}
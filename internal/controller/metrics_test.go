@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ModelService metrics", func() {
+	const metricsNamespace = "metrics"
+
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metricsNamespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespace))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &msv1alpha1.ModelService{}, client.InNamespace(metricsNamespace))).To(Succeed())
+	})
+
+	newModelService := func(name, decodeReady string) *msv1alpha1.ModelService {
+		msvc := &msv1alpha1.ModelService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metricsNamespace},
+			Spec: msv1alpha1.ModelServiceSpec{
+				Routing:        msv1alpha1.Routing{ModelName: "shared-model"},
+				ModelArtifacts: msv1alpha1.ModelArtifacts{URI: "hf://org/model"},
+				Decode:         &msv1alpha1.PDSpec{},
+			},
+		}
+		Expect(k8sClient.Create(ctx, msvc)).To(Succeed())
+		msvc.Status.DecodeReady = decodeReady
+		Expect(k8sClient.Status().Update(ctx, msvc)).To(Succeed())
+		return msvc
+	}
+
+	// gauges returns the value of the metrics named name whose namespace label is metricsNamespace,
+	// keyed by the value of label key
+	gauges := func(families []*dto.MetricFamily, name, key string) map[string]float64 {
+		values := map[string]float64{}
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["namespace"] == metricsNamespace {
+					values[labels[key]] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+		return values
+	}
+
+	It("should report replicas and model name conflicts", func() {
+		newModelService("serving", "2/2")
+		newModelService("starting", "0/1")
+
+		registry := prometheus.NewRegistry()
+		Expect(registry.Register(&modelServiceCollector{reader: k8sClient})).To(Succeed())
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gauges(families, "llmd_modelservice_ready_replicas", "modelservice")).To(Equal(map[string]float64{"serving": 2, "starting": 0}))
+		Expect(gauges(families, "llmd_modelservice_desired_replicas", "modelservice")).To(Equal(map[string]float64{"serving": 2, "starting": 1}))
+		Expect(gauges(families, "llmd_modelservice_model_name_conflicts", "model_name")).To(Equal(map[string]float64{"shared-model": 2}))
+	})
+
+	It("should derive the readiness state from the status", func() {
+		msvc := &msv1alpha1.ModelService{Spec: msv1alpha1.ModelServiceSpec{Decode: &msv1alpha1.PDSpec{}}}
+		Expect(modelServiceState(msvc)).To(Equal(notReadyState))
+
+		msvc.Status.DecodeReady = "1/1"
+		Expect(modelServiceState(msvc)).To(Equal(readyState))
+
+		msvc.Spec.Prefill = &msv1alpha1.PDSpec{}
+		msvc.Status.PrefillReady = "0/1"
+		Expect(modelServiceState(msvc)).To(Equal(notReadyState))
+
+		msvc.Status.Conditions = []metav1.Condition{{Type: baseConfigValidCondition, Status: metav1.ConditionFalse}}
+		Expect(modelServiceState(msvc)).To(Equal(invalidState))
+	})
+})
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// artifactsReadyCondition reports whether the model cache of a ModelService has been populated
+const artifactsReadyCondition = "ArtifactsReady"
+
+// Reasons of the ArtifactsReady condition
+const (
+	downloadedReason     = "Downloaded"
+	downloadingReason    = "Downloading"
+	downloadFailedReason = "DownloadFailed"
+)
+
+// applyModelDownloadJob creates the Job populating the model cache. The pod template
+// of a Job cannot be updated, so a Job downloading other settings is deleted
+// and created again on a later reconcile
+func applyModelDownloadJob(ctx context.Context, r *ModelServiceReconciler, desired *batchv1.Job) error {
+	var job batchv1.Job
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), &job)
+	if errors.IsNotFound(err) {
+		if err := r.Create(ctx, desired.DeepCopy()); err != nil {
+			childResourceApplyFailures.WithLabelValues("Job").Inc()
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if job.Annotations[render.ModelDownloadHashAnnotation] == desired.Annotations[render.ModelDownloadHashAnnotation] {
+		return nil
+	}
+	log.FromContext(ctx).Info("replacing model download job", "job", job.Name)
+	if err := r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
+
+// modelDownloadGate holds the prefill and decode deployments until the
+// model download Job with the desired settings has succeeded
+func (r *ModelServiceReconciler) modelDownloadGate(ctx context.Context, desired *batchv1.Job) (reason, message string, err error) {
+	var job batchv1.Job
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), &job); err != nil {
+		if errors.IsNotFound(err) {
+			return downloadingReason, fmt.Sprintf("waiting for Job %s to be created", desired.Name), nil
+		}
+		return "", "", err
+	}
+	if job.Annotations[render.ModelDownloadHashAnnotation] != desired.Annotations[render.ModelDownloadHashAnnotation] {
+		return downloadingReason, fmt.Sprintf("waiting for Job %s to be replaced", desired.Name), nil
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return "", "", nil
+		case batchv1.JobFailed:
+			return downloadFailedReason, fmt.Sprintf("Job %s failed: %s", job.Name, c.Message), nil
+		}
+	}
+	return downloadingReason, fmt.Sprintf("Job %s is downloading the model", job.Name), nil
+}
+
+// newArtifactsCondition returns the ArtifactsReady condition from the outcome of the download step
+func newArtifactsCondition(outcome stepOutcome, childResources *render.ChildResources) metav1.Condition {
+	if outcome.state == stepApplied {
+		return metav1.Condition{
+			Type:    artifactsReadyCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  downloadedReason,
+			Message: fmt.Sprintf("model downloaded to PersistentVolumeClaim %s", childResources.ModelCachePVC.Name),
+		}
+	}
+	return metav1.Condition{
+		Type:    artifactsReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  outcome.reason,
+		Message: outcome.message,
+	}
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// modelClaimIndex indexes ModelServices by the PersistentVolumeClaim holding their pvc:// model
+const modelClaimIndex = "spec.modelArtifacts.claimName"
+
+// Reasons of the ArtifactsReady condition of pvc:// models
+const (
+	claimBoundReason    = "ClaimBound"
+	claimNotFoundReason = "ClaimNotFound"
+	claimNotBoundReason = "ClaimNotBound"
+)
+
+// indexModelClaim returns the modelClaimIndex value of a ModelService
+func indexModelClaim(obj client.Object) []string {
+	msvc, ok := obj.(*msv1alpha1.ModelService)
+	if !ok {
+		return nil
+	}
+	claimName, ok := render.ModelClaimName(msvc)
+	if !ok {
+		return nil
+	}
+	return []string{claimName}
+}
+
+// newModelClaimCondition returns the ArtifactsReady condition of a pvc:// model,
+// true once its PersistentVolumeClaim is Bound. It does not hold the workloads:
+// claims of WaitForFirstConsumer storage classes are only bound once a pod is scheduled
+func (r *ModelServiceReconciler) newModelClaimCondition(ctx context.Context, namespace, claimName string) (metav1.Condition, error) {
+	var pvc corev1.PersistentVolumeClaim
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: claimName}, &pvc); err != nil {
+		if !errors.IsNotFound(err) {
+			return metav1.Condition{}, err
+		}
+		return metav1.Condition{
+			Type:    artifactsReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  claimNotFoundReason,
+			Message: fmt.Sprintf("PersistentVolumeClaim %s not found", claimName),
+		}, nil
+	}
+
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return metav1.Condition{
+			Type:    artifactsReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  claimNotBoundReason,
+			Message: fmt.Sprintf("PersistentVolumeClaim %s is %s", claimName, pvc.Status.Phase),
+		}, nil
+	}
+	return metav1.Condition{
+		Type:    artifactsReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  claimBoundReason,
+		Message: fmt.Sprintf("PersistentVolumeClaim %s is Bound", claimName),
+	}, nil
+}
+
+// persistentVolumeClaimMapFunc maps a PersistentVolumeClaim to the ModelService
+// owning it, or to the ModelServices serving a pvc:// model from it
+func (r *ModelServiceReconciler) persistentVolumeClaimMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	if shouldReturn, result := requeueMsvcReq(ctx, obj); shouldReturn {
+		return result
+	}
+
+	modelServices := &msv1alpha1.ModelServiceList{}
+	if err := r.List(ctx, modelServices, client.InNamespace(obj.GetNamespace()), client.MatchingFields{modelClaimIndex: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ModelServices for PersistentVolumeClaim", "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(modelServices.Items))
+	for _, msvc := range modelServices.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&msvc)})
+	}
+	return requests
+}
@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// artifactsVerifiedCondition reports whether the model artifacts passed modelArtifacts.verification
+const artifactsVerifiedCondition = "ArtifactsVerified"
+
+// Reasons of the ArtifactsVerified condition
+const (
+	verifiedReason            = "Verified"
+	verificationPendingReason = "VerificationPending"
+	digestMismatchReason      = "DigestMismatch"
+)
+
+// registryUnavailableReason is the reason of the ArtifactsVerified condition while the
+// digest of the model image cannot be resolved in its registry
+const registryUnavailableReason = "RegistryUnavailable"
+
+// imageDigestTTL is how long a digest resolved in a registry is reused, so that
+// reconciles do not query the registry every time
+const imageDigestTTL = 5 * time.Minute
+
+// registryPollInterval is the delay before resolving the digest of an image again
+// after a registry error; registries are not watched
+const registryPollInterval = 30 * time.Second
+
+// registryError is returned when a registry cannot resolve the digest of an image
+type registryError struct {
+	err error
+}
+
+func (e *registryError) Error() string { return e.err.Error() }
+
+func (e *registryError) Unwrap() error { return e.err }
+
+// resolvedDigest is a digest resolved in a registry, reused until expires
+type resolvedDigest struct {
+	digest  string
+	expires time.Time
+}
+
+// digestCache holds the digests resolved by resolveImageDigest, by namespace, image
+// reference and version of the Secret of the credentials. Its zero value is empty
+type digestCache struct {
+	mu      sync.Mutex
+	digests map[string]resolvedDigest
+}
+
+// get returns the digest cached for key, unless it expired at now
+func (c *digestCache) get(key string, now time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resolved, ok := c.digests[key]
+	if !ok || now.After(resolved.expires) {
+		delete(c.digests, key)
+		return "", false
+	}
+	return resolved.digest, true
+}
+
+// set caches digest for key until imageDigestTTL after now
+func (c *digestCache) set(key, digest string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.digests == nil {
+		c.digests = map[string]resolvedDigest{}
+	}
+	c.digests[key] = resolvedDigest{digest: digest, expires: now.Add(imageDigestTTL)}
+}
+
+// dockerConfigJSON is the content of a kubernetes.io/dockerconfigjson Secret
+type dockerConfigJSON struct {
+	Auths map[string]authn.AuthConfig `json:"auths"`
+}
+
+// modelDigestGate holds the workloads until the image of an oci:// URI resolves
+// to the approved digest of modelArtifacts.verification in the registry.
+// The workloads wait with reason RegistryUnavailable while the registry fails
+func (r *ModelServiceReconciler) modelDigestGate(ctx context.Context, msvc *msv1alpha1.ModelService) (reason, message string, err error) {
+	verification := msvc.Spec.ModelArtifacts.Verification
+	reference, ok := render.ModelImageReference(msvc)
+	if !ok || verification == nil || verification.Digest == "" {
+		return "", "", nil
+	}
+
+	digest, err := r.resolveImageDigest(ctx, msvc, reference)
+	var registryErr *registryError
+	if errors.As(err, &registryErr) {
+		return registryUnavailableReason, fmt.Sprintf("unable to resolve the digest of image %s: %v", reference, err), nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("unable to resolve the digest of image %s: %w", reference, err)
+	}
+	if digest != verification.Digest {
+		return digestMismatchReason, fmt.Sprintf("image %s resolves to %s, not to the approved digest %s", reference, digest, verification.Digest), nil
+	}
+	return "", "", nil
+}
+
+// resolveImageDigest returns the digest of reference in its registry, reusing the digest
+// resolved with the same credentials within imageDigestTTL. The registry is authenticated
+// with the .dockerconfigjson of authSecretName, if set, and anonymously otherwise.
+// Errors of the registry are returned as a registryError
+func (r *ModelServiceReconciler) resolveImageDigest(ctx context.Context, msvc *msv1alpha1.ModelService, reference string) (string, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	// a digest resolved with a Secret is only reused with the same version of it,
+	// so that a ModelService cannot resolve an image with the credentials of another
+	key := msvc.Namespace + "/" + reference
+	auth := authn.Anonymous
+	if secretName := msvc.Spec.ModelArtifacts.AuthSecretName; secretName != nil {
+		var secret corev1.Secret
+		if err := r.secretReader().Get(ctx, client.ObjectKey{Namespace: msvc.Namespace, Name: *secretName}, &secret); err != nil {
+			return "", err
+		}
+		key += "@" + secret.Name + "/" + secret.ResourceVersion
+		if auth, err = registryAuth(&secret, ref.Context().RegistryStr()); err != nil {
+			return "", err
+		}
+	}
+	if digest, ok := r.imageDigests.get(key, time.Now()); ok {
+		return digest, nil
+	}
+
+	descriptor, err := remote.Head(ref, remote.WithContext(ctx), remote.WithAuth(auth))
+	if err != nil {
+		return "", &registryError{err: err}
+	}
+	digest := descriptor.Digest.String()
+	r.imageDigests.set(key, digest, time.Now())
+	return digest, nil
+}
+
+// registryAuth returns the credentials of registry in the .dockerconfigjson of
+// secret, or anonymous credentials if it has none
+func registryAuth(secret *corev1.Secret, registry string) (authn.Authenticator, error) {
+	data, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return authn.Anonymous, nil
+	}
+
+	var config dockerConfigJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("secret %s has an invalid %s: %w", secret.Name, corev1.DockerConfigJsonKey, err)
+	}
+	for server, authConfig := range config.Auths {
+		if registryHost(server) == registry {
+			return authn.FromConfig(authConfig), nil
+		}
+	}
+	return authn.Anonymous, nil
+}
+
+// registryHost returns the host of a .dockerconfigjson server, which may be a URL
+func registryHost(server string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	if host == "docker.io" || host == "registry-1.docker.io" {
+		return name.DefaultRegistry
+	}
+	return host
+}
+
+// secretReader returns the reader of Secrets, uncached if APIReader is set
+func (r *ModelServiceReconciler) secretReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// newVerificationCondition returns the ArtifactsVerified condition and the verified digest,
+// empty until the verification passes. The verification init containers have passed once
+// every pod of the prefill and decode deployments is up to date and ready
+func (r *ModelServiceReconciler) newVerificationCondition(ctx context.Context, outcome stepOutcome, childResources *render.ChildResources, msvc *msv1alpha1.ModelService) (metav1.Condition, string, error) {
+	verification := msvc.Spec.ModelArtifacts.Verification
+	if outcome.state != stepApplied {
+		return metav1.Condition{
+			Type:    artifactsVerifiedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  outcome.reason,
+			Message: outcome.message,
+		}, "", nil
+	}
+
+	digest := verification.Digest
+	if digest == "" && len(verification.Manifest) > 0 {
+		var err error
+		if digest, err = render.ManifestDigest(verification.Manifest); err != nil {
+			return metav1.Condition{}, "", err
+		}
+	}
+
+	if len(verification.Manifest) > 0 || verification.Signature != nil {
+		for _, desired := range childResources.PDDeployments() {
+			verified, err := r.deploymentVerified(ctx, desired)
+			if err != nil {
+				return metav1.Condition{}, "", err
+			}
+			if !verified {
+				return metav1.Condition{
+					Type:    artifactsVerifiedCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  verificationPendingReason,
+					Message: fmt.Sprintf("waiting for the verification init containers of deployment %s", desired.Name),
+				}, "", nil
+			}
+		}
+	}
+
+	return metav1.Condition{
+		Type:    artifactsVerifiedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  verifiedReason,
+		Message: fmt.Sprintf("model artifacts verified with digest %s", digest),
+	}, digest, nil
+}
+
+// deploymentVerified reports whether every pod of the deployment in the cluster
+// runs its latest template and is ready, so its init containers have passed
+func (r *ModelServiceReconciler) deploymentVerified(ctx context.Context, desired *appsv1.Deployment) (bool, error) {
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, client.ObjectKey{Name: desired.Name, Namespace: desired.Namespace}, &deployment); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.Replicas > 0 &&
+		status.UpdatedReplicas == status.Replicas &&
+		status.ReadyReplicas == status.Replicas, nil
+}
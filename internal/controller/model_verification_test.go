@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Image digests", func() {
+	It("should not reuse a digest resolved with the credentials of another Secret", func() {
+		By("pushing the model image to a local registry requiring credentials")
+		handler := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if user, password, ok := req.BasicAuth(); !ok || user != "reader" || password != "secret" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="models"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, req)
+		}))
+		defer server.Close()
+		host := strings.TrimPrefix(server.URL, "http://")
+		reference := host + "/models/granite:3.3"
+		image, err := random.Image(1024, 1)
+		Expect(err).NotTo(HaveOccurred())
+		tag, err := name.ParseReference(reference)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(tag, image, remote.WithAuth(&authn.Basic{Username: "reader", Password: "secret"}))).To(Succeed())
+		digest, err := image.Digest()
+		Expect(err).NotTo(HaveOccurred())
+
+		// pullSecret returns a Secret with the credentials of the registry
+		pullSecret := func(secretName, password string) *corev1.Secret {
+			config, err := json.Marshal(dockerConfigJSON{Auths: map[string]authn.AuthConfig{host: {Username: "reader", Password: password}}})
+			Expect(err).NotTo(HaveOccurred())
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: config},
+			}
+		}
+		// verifiedMSVC returns a ModelService reading the image with the Secret secretName
+		verifiedMSVC := func(msvcName, secretName string) *msv1alpha1.ModelService {
+			return &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{Name: msvcName, Namespace: "default"},
+				Spec: msv1alpha1.ModelServiceSpec{
+					ModelArtifacts: msv1alpha1.ModelArtifacts{URI: "oci://" + reference, AuthSecretName: ptr.To(secretName)},
+				},
+			}
+		}
+
+		c := newIndexedClient(pullSecret("granted", "secret"), pullSecret("denied", "wrong"))
+		r := &ModelServiceReconciler{Client: c, Scheme: c.Scheme()}
+		ctx := context.Background()
+
+		resolved, err := r.resolveImageDigest(ctx, verifiedMSVC("granted-msvc", "granted"), reference)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved).To(Equal(digest.String()))
+
+		By("resolving the image again with the Secret of another ModelService")
+		_, err = r.resolveImageDigest(ctx, verifiedMSVC("denied-msvc", "denied"), reference)
+		var registryErr *registryError
+		Expect(err).To(BeAssignableToTypeOf(registryErr))
+	})
+})
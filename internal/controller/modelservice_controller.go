@@ -2,172 +2,102 @@ package controller
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"strings"
-	"text/template"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	"github.com/llm-d/llm-d-model-service/pkg/tracing"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	giev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 )
 
-const HF_PREFIX string = "hf://"
-const PVC_PREFIX string = "pvc://"
-
-// TODO: Decide where to requeue and where to requeueAfter
-
-// RBACOptions provides the options need to create service accounts and
-// role binding during reconcile
-type RBACOptions struct {
-	// EPPPullSecrets contains names of epp pull secrets
-	// the secrets objects are assumed to be in the controller namespace
-	// these are pull secrets used by epp deployment created by the controller
-	EPPPullSecrets []string
-	// PDPullSecrets contains names of pd pull secrets;
-	// the secrets objects are assumed to be in the controller namespace
-	// these are pull secrets used by pd deployment created by the controller
-	PDPullSecrets []string
-	// EPPClusterRole name of the epp cluster role
-	// this is a cluster role used in the rolebinding for the epp deployment
-	EPPClusterRole string
-}
+// Bounds of the exponential backoff applied to ModelServices failing with transient errors
+const (
+	requeueBaseDelay = time.Second
+	requeueMaxDelay  = 5 * time.Minute
+)
+
+// tracer records a span for each stage of Reconcile
+var tracer = otel.Tracer("github.com/llm-d/llm-d-model-service/internal/controller")
+
+// baseConfigValidCondition reports whether the base config could be decoded and merged
+const baseConfigValidCondition = "BaseConfigValid"
+
+// templateVarsResolvedCondition reports whether the ModelService sets every
+// template variable its base config requires
+const templateVarsResolvedCondition = "TemplateVarsResolved"
 
 // ModelServiceReconciler reconciles a ModelService object
 type ModelServiceReconciler struct {
-	RBACOptions RBACOptions
+	RBACOptions     render.RBACOptions
+	ReferencePolicy ReferencePolicy
 	client.Client
 	Scheme *runtime.Scheme
-}
-
-// Context is intended to be use for interpolating template variables
-// in BaseConfig
-type TemplateVars struct {
-	ModelServiceName      string `json:"modelServiceName,omitempty"`
-	ModelServiceNamespace string `json:"modelServiceNamespace,omitempty"`
-	ModelName             string `json:"modelName,omitempty"`
-	HFModelName           string `json:"hfModelName,omitempty"`
-	SanitizedModelName    string `json:"sanitizedModelName,omitempty"`
-	ModelPath             string `json:"modelPath,omitempty"`
-	MountedModelPath      string `json:"mountedModelPath,omitempty"`
-	AuthSecretName        string `json:"authSecretName,omitempty"`
-	EPPServiceName        string `json:"eppServiceName,omitempty"`
-	EPPDeploymentName     string `json:"eppDeploymentName,omitempty"`
-	PrefillDeploymentName string `json:"prefillDeploymentName,omitempty"`
-	DecodeDeploymentName  string `json:"decodeDeploymentName,omitempty"`
-	PrefillServiceName    string `json:"prefillServiceName,omitempty"`
-	DecodeServiceName     string `json:"decodeServiceName,omitempty"`
-	InferencePoolName     string `json:"inferencePoolName,omitempty"`
-	InferenceModelName    string `json:"inferenceModelName,omitempty"`
-}
-
-// from populates the field values for TemplateVars from the model service
-func (t *TemplateVars) from(ctx context.Context, msvc *msv1alpha1.ModelService) error {
-	if t == nil {
-		log.FromContext(ctx).V(1).Info("nil templatevars")
-		return fmt.Errorf("nil templatevars")
-	}
-
-	// non empty template vars; attempt to populate
-	if msvc == nil {
-		log.FromContext(ctx).V(1).Info("empty modelservice; nothing to do")
-		return nil
-	}
-
-	t.ModelServiceName = msvc.Name
-	t.ModelServiceNamespace = msvc.Namespace
-	t.EPPServiceName = eppServiceName(msvc)
-	t.EPPDeploymentName = eppDeploymentName(msvc)
-	t.PrefillDeploymentName = deploymentName(msvc, PREFILL_ROLE)
-	t.DecodeDeploymentName = deploymentName(msvc, DECODE_ROLE)
-	t.PrefillServiceName = sanitizeSvcName(msvc, PREFILL_ROLE)
-	t.DecodeServiceName = sanitizeSvcName(msvc, DECODE_ROLE)
-	t.InferencePoolName = infPoolName(msvc)
-	t.InferenceModelName = infModelName(msvc)
-	t.ModelName = msvc.Spec.Routing.ModelName
-	t.SanitizedModelName = sanitizeModelName(msvc)
-
-	if msvc.Spec.ModelArtifacts.AuthSecretName != nil {
-		t.AuthSecretName = *msvc.Spec.ModelArtifacts.AuthSecretName
-	}
-
-	uri := msvc.Spec.ModelArtifacts.URI
-	if strings.HasPrefix(uri, HF_PREFIX) {
-		t.HFModelName = strings.TrimPrefix(uri, HF_PREFIX)
-		t.ModelPath = t.HFModelName
-	} else if strings.HasPrefix(uri, PVC_PREFIX) {
-		tail := strings.TrimPrefix(uri, PVC_PREFIX)
-		segments := strings.Split(tail, pathSep)
-		t.ModelPath = strings.Join(segments[1:], pathSep)
-	} else {
-		err := fmt.Errorf("unsupported prefix")
-		log.FromContext(ctx).V(1).Error(err, "cannot get template vars", "uri", uri)
-		return err
-	}
-
-	// Compute the mountedModelPath variable, given the URI type
-	// PVC: /path/to/model
-	// HF: /model-cache
-	// OCI: /model-cache
-	mountedModelPath, err := mountedModelPath(msvc)
-	if err != nil {
-		return err
-	}
-	t.MountedModelPath = mountedModelPath
-
-	return nil
-
-}
-
-type TemplateFuncs struct {
-	funcMap template.FuncMap
-}
-
-// from populates the field values for TemplateVars from the model service
-func (t *TemplateFuncs) from(ctx context.Context, msvc *msv1alpha1.ModelService) {
-
-	fn := func(name string) int32 {
-		for _, p := range msvc.Spec.Routing.Ports {
-			if p.Name == name {
-				return p.Port
-			}
-		}
-		log.FromContext(ctx).V(1).Info("unknown port", "name", name, "ports", msvc.Spec.Routing.Ports)
-		return -1
-	}
-
-	t.funcMap["getPort"] = fn
+	// Recorder emits the Events of ModelServices; no Event is emitted if nil
+	Recorder record.EventRecorder
+	// APIReader reads the registry credentials of modelArtifacts.verification,
+	// so that Secrets are not cached; the client is used if nil
+	APIReader client.Reader
+	// imageDigests caches the digests of the model images resolved in their registry
+	imageDigests digestCache
 }
 
 // +kubebuilder:rbac:groups=llm-d.ai,resources=modelservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=llm-d.ai,resources=modelservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=llm-d.ai,resources=modelservices/finalizers,verbs=update
+// +kubebuilder:rbac:groups=llm-d.ai,resources=modelservicebaseconfigs;clustermodelservicebaseconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=llm-d.ai,resources=baseconfiggrants,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/scale,verbs=update;patch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferencemodels,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferencepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
 
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.20.4/pkg/reconcile
-func (r *ModelServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ModelServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracer.Start(ctx, "Reconcile", trace.WithAttributes(tracing.ObjectAttributes("ModelService", req.Namespace, req.Name)...))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.reconcile(ctx, req)
+}
+
+// reconcile implements Reconcile within its span
+func (r *ModelServiceReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log.FromContext(ctx).V(1).Info("ModelService Reconciler started")
 
 	// Step 1: Check that the model service is valid:
@@ -181,57 +111,142 @@ func (r *ModelServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			return ctrl.Result{}, nil
 		}
 		log.FromContext(ctx).V(1).Error(err, "Unable to get ModelService")
-		// should we requeue?  Neurops controller does.
-		// Others do not always. See, for example https://github.com/kubernetes-sigs/kueue/blob/e9b35497ccf5b0534cce64a2a5f71c81b0926d6d/pkg/controller/core/workload_controller.go#L145
-		// and https://github.com/kubernetes-sigs/gateway-api-inference-extension/blob/bd9ee36450d68fb4d0d8ac4f9be4db7d1ec4fee3/pkg/epp/controller/inferencepool_reconciler.go#L53
-		// if we don't requeue there is a utility method we could use: client.IgnoreNotFound(err)
-		return ctrl.Result{Requeue: true}, err
+		// transient API errors are requeued with exponential backoff
+		return ctrl.Result{}, err
 	} else if !modelService.DeletionTimestamp.IsZero() {
 		log.FromContext(ctx).V(1).Info("ModelService is marked for deletion")
 		return ctrl.Result{}, nil
 	}
 
-	// Step 1.1: interpolate the modelService since it can include template vars
-	interpolatedModelService, err := InterpolateModelService(ctx, modelService)
+	log.FromContext(ctx).V(1).Info("attempting to get baseconfig object")
+	// Step 2: Get the baseconfig object and its parents if it exists
+	baseConfigChain, chainRefs, err := r.getBaseConfigChain(ctx, modelService)
+	// the chain is recorded even if a layer is missing, so that its creation is mapped to the ModelService
+	if statusErr := r.setBaseConfigChain(ctx, modelService, chainRefs); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
 	if err != nil {
+		notFound := errors.IsNotFound(err)
+		permanent := isPermanentError(err)
+		if notFound || permanent {
+			if statusErr := r.setBaseConfigCondition(ctx, modelService, newBaseConfigCondition(nil, err)); statusErr != nil {
+				log.FromContext(ctx).Error(statusErr, "unable to report base config condition")
+			}
+		}
+		switch {
+		case notFound:
+			// every base config of the chain is watched; its creation triggers a reconcile
+			log.FromContext(ctx).Info("base config not found", "error", err.Error())
+			return ctrl.Result{}, nil
+		case permanent:
+			return ctrl.Result{}, reconcile.TerminalError(err)
+		}
 		return ctrl.Result{}, err
 	}
-
-	log.FromContext(ctx).V(1).Info("attempting to get baseconfig object")
-	// Step 2: Get the interpolated baseconfig object if it exists
-	interpolatedBaseConfig, err := r.getChildResourcesFromConfigMap(ctx, interpolatedModelService)
+	var (
+		baseConfigMap *corev1.ConfigMap
+		parents       []render.BaseConfigLayer
+	)
+	if n := len(baseConfigChain); n > 0 {
+		baseConfigMap = baseConfigChain[n-1].ConfigMap
+		parents = baseConfigChain[:n-1]
+	}
+
+	// Step 3: Render the child resources from the modelService and the baseconfig
+	renderStart := time.Now()
+	childResources, err := render.Render(ctx, modelService, baseConfigMap, render.Options{
+		Scheme:      r.Scheme,
+		RBACOptions: r.RBACOptions,
+		Parents:     parents,
+	})
+	templateRenderDuration.Observe(time.Since(renderStart).Seconds())
+	baseConfigCondition := newBaseConfigCondition(baseConfigMap, err)
+	templateVarsCondition := newTemplateVarsCondition(modelService, err)
 	if err != nil {
-		return ctrl.Result{}, err
+		log.FromContext(ctx).Error(err, "unable to render child resources")
+		// missing template variables are an error of the ModelService, not of its base config
+		if templateVarsCondition != nil && templateVarsCondition.Status == metav1.ConditionFalse {
+			if statusErr := r.setCondition(ctx, modelService, *templateVarsCondition); statusErr != nil {
+				log.FromContext(ctx).Error(statusErr, "unable to report template variables condition")
+			}
+		} else if statusErr := r.setBaseConfigCondition(ctx, modelService, baseConfigCondition); statusErr != nil {
+			log.FromContext(ctx).Error(statusErr, "unable to report base config condition")
+		}
+		// rendering only depends on the ModelService and its base config, which are watched
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	}
+	if unknownKeys := render.UnknownBaseConfigKeys(baseConfigMap); len(unknownKeys) > 0 {
+		log.FromContext(ctx).Info("ignoring unknown base config keys", "keys", unknownKeys)
 	}
 
-	interpolatedBaseConfig = interpolatedBaseConfig.MergeChildResources(ctx, interpolatedModelService, r.Scheme, &r.RBACOptions)
+	// child ConfigMaps stay in the namespace of the ModelService unless a BaseConfigGrant allows otherwise
+	if err := r.checkChildConfigMaps(ctx, modelService, childResources.ConfigMaps); err != nil {
+		if !isPermanentError(err) {
+			return ctrl.Result{}, err
+		}
+		if statusErr := r.setBaseConfigCondition(ctx, modelService, newBaseConfigCondition(baseConfigMap, err)); statusErr != nil {
+			log.FromContext(ctx).Error(statusErr, "unable to report base config condition")
+		}
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	}
+
+	// changes of a shared base config are rolled out to a limited number of ModelServices at once
+	revision := render.BaseConfigRevision(baseConfigChain)
+	if baseConfigMap != nil {
+		admit, propagatedCondition, err := r.admitBaseConfigRevision(ctx, modelService, baseConfigChain, revision)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !admit {
+			log.FromContext(ctx).Info("holding base config revision", "revision", revision, "reason", propagatedCondition.Reason)
+			r.recordPropagationEvent(modelService, modelService.Status.Conditions, *propagatedCondition)
+			if err := r.setCondition(ctx, modelService, *propagatedCondition); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: propagationPollInterval}, nil
+		}
+	}
 
 	// TODO: Post-process for decoupled Scaling
 	log.FromContext(ctx).V(1).Info("creating or updating child resources now")
 
-	errs := interpolatedBaseConfig.invokeCreateOrUpdate(ctx, r, interpolatedModelService)
+	// the workloads wait for the model download and the HTTPRoute waits for the
+	// EPP and the endpoints of the pool; they are watched, so later reconciles apply them
+	steps, errs := r.invokeCreateOrUpdate(ctx, childResources, modelService)
 
 	if len(errs) > 0 {
 		log.FromContext(ctx).Error(fmt.Errorf("problem creating %d child resources", len(errs)), "createOrUpdate failed")
 
-		// TODO: requeue here?
-		// Return the last error
-		return ctrl.Result{}, errs[len(errs)-1]
+		// resources rejected by the API server are not retried until the ModelService changes
+		for _, e := range errs {
+			if !isPermanentError(e) {
+				return ctrl.Result{}, stderrors.Join(errs...)
+			}
+		}
+		return ctrl.Result{}, reconcile.TerminalError(stderrors.Join(errs...))
 	}
 
 	//update status
-	err = r.populateStatus(ctx, interpolatedModelService)
+	renderConditions := []metav1.Condition{baseConfigCondition}
+	if templateVarsCondition != nil {
+		renderConditions = append(renderConditions, *templateVarsCondition)
+	}
+	err = r.populateStatus(ctx, modelService, childResources, renderConditions, steps, revision)
 	if err != nil {
 		// modelservice could be deleted before populating status
 		// next reconcile cycle should ignore this request
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: steps.requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ModelServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	// the indexes of ModelServices are registered by SetupIndexes
+	builder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](requeueBaseDelay, requeueMaxDelay),
+		}).
 		For(&msv1alpha1.ModelService{}).
 		Named("modelservice").
 		Owns(&msv1alpha1.ModelService{}).
@@ -243,7 +258,44 @@ func (r *ModelServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&giev1alpha2.InferenceModel{}, handler.EnqueueRequestsFromMapFunc(r.inferenceModelMapFunc)).
 		Watches(&giev1alpha2.InferencePool{}, handler.EnqueueRequestsFromMapFunc(r.inferencePoolMapFunc)).
 		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(r.serviceAccountMapFunc)).
-		Complete(r)
+		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.persistentVolumeClaimMapFunc)).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.ownedMapFunc)).
+		Watches(&msv1alpha1.ModelServiceBaseConfig{}, handler.EnqueueRequestsFromMapFunc(r.baseConfigMapFunc)).
+		Watches(&msv1alpha1.ClusterModelServiceBaseConfig{}, handler.EnqueueRequestsFromMapFunc(r.baseConfigMapFunc)).
+		Watches(&msv1alpha1.BaseConfigGrant{}, handler.EnqueueRequestsFromMapFunc(r.baseConfigGrantMapFunc))
+
+	// the Prometheus Operator is optional; PodMonitors are only watched if their CRD is installed
+	podMonitorKind := monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.PodMonitorsKind)
+	if _, err := mgr.GetRESTMapper().RESTMapping(podMonitorKind.GroupKind(), podMonitorKind.Version); err == nil {
+		builder = builder.Watches(&monitoringv1.PodMonitor{}, handler.EnqueueRequestsFromMapFunc(r.podMonitorMapFunc))
+	} else {
+		mgr.GetLogger().Info("PodMonitor CRD not found, not watching PodMonitors", "error", err.Error())
+	}
+
+	return builder.Complete(r)
+}
+
+// isPermanentError reports whether err cannot be fixed by retrying, until
+// the ModelService, its base config or a BaseConfigGrant change
+func isPermanentError(err error) bool {
+	var (
+		decodeErrs render.DecodeErrors
+		decodeErr  *render.DecodeError
+		cycleErr   *render.CycleError
+		notPermErr *ReferenceNotPermittedError
+	)
+	return stderrors.As(err, &decodeErrs) || stderrors.As(err, &decodeErr) ||
+		stderrors.As(err, &cycleErr) || stderrors.As(err, &notPermErr) ||
+		errors.IsInvalid(err) || errors.IsBadRequest(err)
+}
+
+// setTransitionTime keeps the transition time of condition from existing if its status did not change
+func setTransitionTime(existing []metav1.Condition, condition *metav1.Condition) {
+	if previous := meta.FindStatusCondition(existing, condition.Type); previous != nil && previous.Status == condition.Status {
+		condition.LastTransitionTime = previous.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = metav1.Now()
+	}
 }
 
 // deploymentMapFunc maps deployments to ModelService owner
@@ -272,41 +324,167 @@ func (r *ModelServiceReconciler) deploymentMapFunc(ctx context.Context, obj clie
 	return []reconcile.Request{}
 }
 
-func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1alpha1.ModelService) error {
+// newBaseConfigCondition returns the BaseConfigValid condition describing
+// the outcome of rendering baseConfigMap; renderErr is the error returned by render.Render
+// or by the reference policy
+func newBaseConfigCondition(baseConfigMap *corev1.ConfigMap, renderErr error) metav1.Condition {
+	if renderErr == nil {
+		if unknownKeys := render.UnknownBaseConfigKeys(baseConfigMap); len(unknownKeys) > 0 {
+			return metav1.Condition{
+				Type:    baseConfigValidCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "UnknownKeys",
+				Message: fmt.Sprintf("ignoring unknown base config keys: %s", strings.Join(unknownKeys, ", ")),
+			}
+		}
+		return metav1.Condition{
+			Type:    baseConfigValidCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Valid",
+			Message: "base config decoded and merged",
+		}
+	}
+
+	var (
+		decodeErrs  render.DecodeErrors
+		decodeErr   *render.DecodeError
+		templateErr *render.TemplateError
+		mergeErr    *render.MergeError
+		cycleErr    *render.CycleError
+		notPermErr  *ReferenceNotPermittedError
+	)
+	reason := "RenderFailed"
+	switch {
+	case errors.IsNotFound(renderErr):
+		reason = "NotFound"
+	case stderrors.As(renderErr, &decodeErrs), stderrors.As(renderErr, &decodeErr):
+		reason = "DecodeFailed"
+	case stderrors.As(renderErr, &cycleErr):
+		reason = "ParentCycle"
+	case stderrors.As(renderErr, &notPermErr):
+		reason = "ReferenceNotPermitted"
+	case stderrors.As(renderErr, &templateErr):
+		reason = "TemplateFailed"
+	case stderrors.As(renderErr, &mergeErr):
+		reason = "MergeFailed"
+	}
+
+	return metav1.Condition{
+		Type:    baseConfigValidCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: renderErr.Error(),
+	}
+}
+
+// newTemplateVarsCondition returns the TemplateVarsResolved condition of msvc given the error
+// returned by render.Render. It is only reported once a required template variable was
+// missing, and nil is returned otherwise, or if rendering failed for another reason
+func newTemplateVarsCondition(msvc *msv1alpha1.ModelService, renderErr error) *metav1.Condition {
+	var missingErr *render.MissingTemplateVarsError
+	if stderrors.As(renderErr, &missingErr) {
+		return &metav1.Condition{
+			Type:    templateVarsResolvedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "MissingTemplateVars",
+			Message: renderErr.Error(),
+		}
+	}
+	if renderErr != nil || meta.FindStatusCondition(msvc.Status.Conditions, templateVarsResolvedCondition) == nil {
+		return nil
+	}
+	return &metav1.Condition{
+		Type:    templateVarsResolvedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Resolved",
+		Message: "every required template variable is set",
+	}
+}
+
+// setBaseConfigCondition records condition on the ModelService status
+// without touching the rest of the status. It is used when the child
+// resources cannot be rendered and populateStatus is not reached.
+// A failed condition is counted by the base_config_errors_total metric
+func (r *ModelServiceReconciler) setBaseConfigCondition(ctx context.Context, msvc *msv1alpha1.ModelService, condition metav1.Condition) error {
+	if condition.Status == metav1.ConditionFalse {
+		baseConfigErrors.WithLabelValues(condition.Reason).Inc()
+	}
+
+	return r.setCondition(ctx, msvc, condition)
+}
+
+// setBaseConfigChain records the base config chain of msvc on its status, if it changed,
+// without touching the rest of the status
+func (r *ModelServiceReconciler) setBaseConfigChain(ctx context.Context, msvc *msv1alpha1.ModelService, chain []msv1alpha1.BaseConfigLayerReference) error {
+	if equality.Semantic.DeepEqual(msvc.Status.BaseConfigChain, chain) {
+		return nil
+	}
+	msvc.Status.BaseConfigChain = chain
+
+	latest := &msv1alpha1.ModelService{}
+	if err := r.Get(ctx, types.NamespacedName{Name: msvc.Name, Namespace: msvc.Namespace}, latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	latest.Status.BaseConfigChain = chain
+	return client.IgnoreNotFound(r.Status().Update(ctx, latest))
+}
+
+// setCondition records condition on the ModelService status without touching the rest of the status
+func (r *ModelServiceReconciler) setCondition(ctx context.Context, msvc *msv1alpha1.ModelService, condition metav1.Condition) error {
+	latest := &msv1alpha1.ModelService{}
+	if err := r.Get(ctx, types.NamespacedName{Name: msvc.Name, Namespace: msvc.Namespace}, latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !meta.SetStatusCondition(&latest.Status.Conditions, condition) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Status().Update(ctx, latest))
+}
+
+func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1alpha1.ModelService, childResources *render.ChildResources, renderConditions []metav1.Condition, steps stepStatus, revision string) (err error) {
+	ctx, span := tracer.Start(ctx, "populateStatus")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var conditions []metav1.Condition
 	totalReady, expected := int32(0), int32(0)
 	original := msvc.DeepCopy()
-	baseConfig, err := r.getChildResourcesFromConfigMap(ctx, msvc)
-	if err != nil {
-		return err
+
+	for _, condition := range append(renderConditions, steps.conditions...) {
+		setTransitionTime(original.Status.Conditions, &condition)
+		conditions = append(conditions, condition)
 	}
 
-	httpRouteName := httpRouteName(msvc)
+	httpRouteName := render.HTTPRouteName(msvc)
 	msvc.Status.HTTPRouteRef = &httpRouteName
 
-	infModelName := infModelName(msvc)
+	infModelName := render.InferenceModelName(msvc)
 	msvc.Status.InferenceModelRef = &infModelName
 
-	infPoolName := infPoolName(msvc)
+	infPoolName := render.InferencePoolName(msvc)
 	msvc.Status.InferencePoolRef = &infPoolName
 
-	pdSA := pdServiceAccountName(msvc)
+	pdSA := render.PDServiceAccountName(msvc)
 	msvc.Status.PDServiceAccountRef = &pdSA
 
-	eppSA := eppServiceAccountName(msvc)
+	eppSA := render.EPPServiceAccountName(msvc)
 	msvc.Status.PDServiceAccountRef = &eppSA
 
-	eppRoleBinding := eppRolebindingName(msvc)
+	eppRoleBinding := render.EPPRoleBindingName(msvc)
 	msvc.Status.EppRoleBinding = &eppRoleBinding
 
+	if childResources.PodMonitor != nil {
+		podMonitorName := render.PodMonitorName(msvc)
+		msvc.Status.PodMonitorRef = &podMonitorName
+	}
+
 	var configMapNames []string
-	for _, v := range baseConfig.ConfigMaps {
+	for _, v := range childResources.ConfigMaps {
 		configMapNames = append(configMapNames, v.Name)
 	}
 	msvc.Status.ConfigMapNames = configMapNames
 
 	if msvc.Spec.Prefill != nil {
-		prefillDeploymentName := deploymentName(msvc, PREFILL_ROLE)
+		prefillDeploymentName := render.DeploymentName(msvc, render.PREFILL_ROLE)
 		msvc.Status.PrefillDeploymentRef = &prefillDeploymentName
 		prefillDeploymentFromCluster := &appsv1.Deployment{}
 		// Mirror conditions with "Prefill" prefix
@@ -324,10 +502,22 @@ func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1a
 			totalReady = prefillDeploymentFromCluster.Status.ReadyReplicas
 			totalAvailable := prefillDeploymentFromCluster.Status.AvailableReplicas
 			expected = *prefillDeploymentFromCluster.Spec.Replicas
+
+			// the deployments of the accelerator overrides count towards the role
+			ready, available, acceleratorExpected, stalled, err := r.acceleratorReplicas(ctx, childResources.PrefillAcceleratorDeployments)
+			if err != nil {
+				return err
+			}
+			totalReady += ready
+			totalAvailable += available
+			expected += acceleratorExpected
 			msvc.Status.PrefillReady = fmt.Sprintf("%d/%d", totalReady, expected)
 			msvc.Status.PrefillAvailable = totalAvailable
 
 			for _, c := range prefillDeploymentFromCluster.Status.Conditions {
+				if c.Type == appsv1.DeploymentProgressing && stalled != nil {
+					c = *stalled
+				}
 				conditions = append(conditions, metav1.Condition{
 					Type:               "Prefill" + string(c.Type),
 					Status:             metav1.ConditionStatus(c.Status),
@@ -340,7 +530,7 @@ func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1a
 	}
 
 	if msvc.Spec.Decode != nil {
-		decodeDeploymentName := deploymentName(msvc, DECODE_ROLE)
+		decodeDeploymentName := render.DeploymentName(msvc, render.DECODE_ROLE)
 		msvc.Status.DecodeDeploymentRef = &decodeDeploymentName
 		decodeDeploymentFromCluster := &appsv1.Deployment{}
 		err := r.Get(ctx, client.ObjectKey{Name: decodeDeploymentName, Namespace: msvc.Namespace}, decodeDeploymentFromCluster)
@@ -357,11 +547,23 @@ func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1a
 			totalReady := decodeDeploymentFromCluster.Status.ReadyReplicas
 			totalAvailable := decodeDeploymentFromCluster.Status.AvailableReplicas
 			expected := *decodeDeploymentFromCluster.Spec.Replicas
+
+			// the deployments of the accelerator overrides count towards the role
+			ready, available, acceleratorExpected, stalled, err := r.acceleratorReplicas(ctx, childResources.DecodeAcceleratorDeployments)
+			if err != nil {
+				return err
+			}
+			totalReady += ready
+			totalAvailable += available
+			expected += acceleratorExpected
 			msvc.Status.DecodeReady = fmt.Sprintf("%d/%d", totalReady, expected)
 			msvc.Status.DecodeAvailable = totalAvailable
 
 			// Mirror conditions with "Decode" prefix
 			for _, c := range decodeDeploymentFromCluster.Status.Conditions {
+				if c.Type == appsv1.DeploymentProgressing && stalled != nil {
+					c = *stalled
+				}
 				conditions = append(conditions, metav1.Condition{
 					Type:               "Decode" + string(c.Type),
 					Status:             metav1.ConditionStatus(c.Status),
@@ -373,8 +575,8 @@ func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1a
 		}
 	}
 
-	if baseConfig.EPPDeployment != nil {
-		eppName := eppDeploymentName(msvc)
+	if childResources.EPPDeployment != nil {
+		eppName := render.EPPDeploymentName(msvc)
 		msvc.Status.EppDeploymentRef = &eppName
 		eppDeploymentFromCluster := &appsv1.Deployment{}
 		err := r.Get(ctx, client.ObjectKey{Name: eppName, Namespace: msvc.Namespace}, eppDeploymentFromCluster)
@@ -409,6 +611,21 @@ func (r *ModelServiceReconciler) populateStatus(ctx context.Context, msvc *msv1a
 
 	msvc.Status.Conditions = conditions
 
+	// Ready is derived from the READY statuses and conditions above
+	ready := newReadyCondition(msvc)
+	setTransitionTime(original.Status.Conditions, &ready)
+	msvc.Status.Conditions = append(msvc.Status.Conditions, ready)
+
+	msvc.Status.ObservedGeneration = msvc.Generation
+	msvc.Status.BaseConfigRevision = revision
+	msvc.Status.VerifiedDigest = steps.verifiedDigest
+	if revision != "" {
+		propagated := newPropagatedCondition(msvc, revision)
+		setTransitionTime(original.Status.Conditions, &propagated)
+		r.recordPropagationEvent(msvc, original.Status.Conditions, propagated)
+		msvc.Status.Conditions = append(msvc.Status.Conditions, propagated)
+	}
+
 	latest := &msv1alpha1.ModelService{}
 	if err := r.Get(ctx, types.NamespacedName{Name: msvc.Name, Namespace: msvc.Namespace}, latest); err != nil {
 		if errors.IsNotFound(err) {
@@ -467,6 +684,35 @@ func (r *ModelServiceReconciler) roleBindingMapFunc(ctx context.Context, obj cli
 	return nil
 }
 
+// baseConfigMapFunc maps a ModelServiceBaseConfig or ClusterModelServiceBaseConfig
+// to the ModelServices inheriting from it, directly or as a parent
+func (r *ModelServiceReconciler) baseConfigMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	var kind string
+	switch obj.(type) {
+	case *msv1alpha1.ModelServiceBaseConfig:
+		kind = msv1alpha1.ModelServiceBaseConfigKind
+	case *msv1alpha1.ClusterModelServiceBaseConfig:
+		kind = msv1alpha1.ClusterModelServiceBaseConfigKind
+	default:
+		return nil
+	}
+
+	target := client.ObjectKeyFromObject(obj)
+	modelServices, err := modelServicesIndexedBy(ctx, r.Client, baseConfigChainIndex, kind, target)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ModelServices for base config", "kind", kind, "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(modelServices))
+	for _, msvc := range modelServices {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&msvc)})
+	}
+	return requests
+}
+
+// configMapMapFunc maps a ConfigMap to the ModelService owning it,
+// or to the ModelServices inheriting from it as their base config or a parent
 func (r *ModelServiceReconciler) configMapMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
 	cm, ok := obj.(*corev1.ConfigMap)
 	if !ok {
@@ -476,7 +722,17 @@ func (r *ModelServiceReconciler) configMapMapFunc(ctx context.Context, obj clien
 	if shouldReturn {
 		return result
 	}
-	return nil
+
+	modelServices, err := modelServicesIndexedBy(ctx, r.Client, baseConfigChainIndex, msv1alpha1.ConfigMapKind, client.ObjectKeyFromObject(cm))
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ModelServices for base config", "kind", msv1alpha1.ConfigMapKind, "name", cm.Name)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(modelServices))
+	for _, msvc := range modelServices {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&msvc)})
+	}
+	return requests
 }
 
 func (r *ModelServiceReconciler) inferencePoolMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -503,6 +759,24 @@ func (r *ModelServiceReconciler) httpRouteMapFunc(ctx context.Context, obj clien
 	return nil
 }
 
+func (r *ModelServiceReconciler) podMonitorMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	pm, ok := obj.(*monitoringv1.PodMonitor)
+	if !ok {
+		return nil
+	}
+	shouldReturn, result := requeueMsvcReq(ctx, pm)
+	if shouldReturn {
+		return result
+	}
+	return nil
+}
+
+// ownedMapFunc maps an object to the ModelService owning it
+func (r *ModelServiceReconciler) ownedMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	_, result := requeueMsvcReq(ctx, obj)
+	return result
+}
+
 func (r *ModelServiceReconciler) inferenceModelMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
 	im, ok := obj.(*giev1alpha2.InferenceModel)
 	if !ok {
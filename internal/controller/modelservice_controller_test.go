@@ -3,11 +3,26 @@ package controller
 import (
 	"context"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,6 +33,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 
 	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	giev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
@@ -133,7 +149,7 @@ data:
         port: 9002
         targetPort: 9002
         appProtocol: http2
-    type: ClusterIP
+      type: ClusterIP
   httpRoute: |
     spec:
       parentRefs:
@@ -163,7 +179,7 @@ data:
 var imageName = "quay.io/redhattraining/hello-world-nginx"
 
 var _ = Describe("ModelService Controller", func() {
-	var rbacOptions *RBACOptions
+	var rbacOptions *render.RBACOptions
 
 	Context("When reconciling a resource", func() {
 
@@ -176,7 +192,7 @@ var _ = Describe("ModelService Controller", func() {
 			ctx := context.Background()
 
 			// Set RBAC options with EPPPullSecrets and PDPullSecrets
-			rbacOptions = &RBACOptions{
+			rbacOptions = &render.RBACOptions{
 				EPPPullSecrets: []string{"epp-pull-secret"},
 				PDPullSecrets:  []string{"secret1", "secret2"},
 				EPPClusterRole: "epp-cluster-role",
@@ -259,7 +275,7 @@ var _ = Describe("ModelService Controller", func() {
 			reconciler := &ModelServiceReconciler{
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
-				RBACOptions: RBACOptions{
+				RBACOptions: render.RBACOptions{
 					EPPPullSecrets: []string{"epp-pull-secret"},
 					PDPullSecrets:  []string{"secret1", "secret2"},
 					EPPClusterRole: "epp-cluster-role",
@@ -369,12 +385,12 @@ var _ = Describe("ModelService Controller", func() {
 					return "", nil
 				}
 				return *ms.Status.DecodeDeploymentRef, nil
-			}, time.Second*5, time.Millisecond*500).Should(Equal(deploymentName(modelService, "decode")))
+			}, time.Second*5, time.Millisecond*500).Should(Equal(render.DeploymentName(modelService, "decode")))
 
 			By("Ensuring decode deployment has a PVC volume")
 			decodePodVolume := decode.Spec.Template.Spec.Volumes
 			Expect(len(decodePodVolume)).To(Equal(1))
-			Expect(decodePodVolume[0].Name).To(Equal(modelStorageVolumeName))
+			Expect(decodePodVolume[0].Name).To(Equal(render.ModelStorageVolumeName))
 			Expect(decodePodVolume[0].PersistentVolumeClaim.ClaimName).To(Equal(llamaPVCName))
 
 			By("Ensuring decode deployment has a volume mount for PVC")
@@ -384,8 +400,9 @@ var _ = Describe("ModelService Controller", func() {
 			Expect(len(firstDecodeContainerMount)).To(Equal(1))
 
 			By("Ensuring decode deployment's container volume mount has the correct name and mount path")
-			Expect(firstDecodeContainerMount[0].Name).To(Equal(modelStorageVolumeName))
-			Expect(firstDecodeContainerMount[0].MountPath).To(Equal(modelStorageRoot))
+			Expect(firstDecodeContainerMount[0].Name).To(Equal(render.ModelStorageVolumeName))
+			Expect(firstDecodeContainerMount[0].MountPath).To(Equal(render.ModelStorageRoot + "/path/to/model"))
+			Expect(firstDecodeContainerMount[0].SubPath).To(Equal("path/to/model"))
 
 			By("Deleting the decode deployment")
 			Expect(k8sClient.Delete(ctx, &decode)).To(Succeed())
@@ -399,7 +416,7 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: deploymentName(modelService, "decode"), Namespace: namespace}, &decode)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.DeploymentName(modelService, "decode"), Namespace: namespace}, &decode)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
@@ -428,7 +445,7 @@ var _ = Describe("ModelService Controller", func() {
 			By("Ensuring prefill deployment has a PVC volume")
 			prefillPodVolume := prefill.Spec.Template.Spec.Volumes
 			Expect(len(prefillPodVolume)).To(Equal(1))
-			Expect(prefillPodVolume[0].Name).To(Equal(modelStorageVolumeName))
+			Expect(prefillPodVolume[0].Name).To(Equal(render.ModelStorageVolumeName))
 			Expect(prefillPodVolume[0].PersistentVolumeClaim.ClaimName).To(Equal(llamaPVCName))
 
 			By("Ensuring prefill deployment doesn't have a volume mount for PVC")
@@ -449,7 +466,7 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: deploymentName(modelService, "prefill"), Namespace: namespace}, &prefill)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.DeploymentName(modelService, "prefill"), Namespace: namespace}, &prefill)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
@@ -465,21 +482,21 @@ var _ = Describe("ModelService Controller", func() {
 					return "", nil
 				}
 				return *ms.Status.PrefillDeploymentRef, nil
-			}, time.Second*5, time.Millisecond*500).Should(Equal(deploymentName(modelService, "prefill")))
+			}, time.Second*5, time.Millisecond*500).Should(Equal(render.DeploymentName(modelService, "prefill")))
 
 			By("Checking if a PD SA was created")
 			sa := corev1.ServiceAccount{}
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: pdServiceAccountName(modelService), Namespace: namespace}, &sa)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.PDServiceAccountName(modelService), Namespace: namespace}, &sa)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 
 			By("Checking if PD SA has the corret owner reference")
-			Expect(sa.Name).To(Equal(pdServiceAccountName(modelService)))
+			Expect(sa.Name).To(Equal(render.PDServiceAccountName(modelService)))
 			Expect(sa.OwnerReferences).ToNot(BeEmpty())
 
 			By("Checking that prefill is using the correct SA")
-			Expect(prefill.Spec.Template.Spec.ServiceAccountName).To(Equal(pdServiceAccountName(modelService)))
+			Expect(prefill.Spec.Template.Spec.ServiceAccountName).To(Equal(render.PDServiceAccountName(modelService)))
 
 			actualSecrets := make([]string, len(sa.ImagePullSecrets))
 			for i, s := range sa.ImagePullSecrets {
@@ -501,14 +518,14 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: pdServiceAccountName(modelService), Namespace: namespace}, &sa)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.PDServiceAccountName(modelService), Namespace: namespace}, &sa)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
 			By("Validating that the EPP ServiceAccount was created")
 			eppSA := corev1.ServiceAccount{}
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: eppServiceAccountName(modelService), Namespace: namespace}, &eppSA)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.EPPServiceAccountName(modelService), Namespace: namespace}, &eppSA)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 
@@ -521,7 +538,7 @@ var _ = Describe("ModelService Controller", func() {
 			Expect(actualSecrets).To(Equal(expectedSecrets))
 
 			By("Checking if epp SA has the correct owner reference")
-			Expect(eppSA.Name).To(Equal(eppServiceAccountName(modelService)))
+			Expect(eppSA.Name).To(Equal(render.EPPServiceAccountName(modelService)))
 			Expect(eppSA.OwnerReferences).ToNot(BeEmpty())
 
 			By("Deleting the epp service account")
@@ -536,14 +553,14 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: eppServiceAccountName(modelService), Namespace: namespace}, &eppSA)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.EPPServiceAccountName(modelService), Namespace: namespace}, &eppSA)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
 			By("Checking if a epp RoleBinding was created")
 			rolebinding := rbacv1.RoleBinding{}
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: eppRolebindingName(modelService), Namespace: namespace}, &rolebinding)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.EPPRoleBindingName(modelService), Namespace: namespace}, &rolebinding)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 
@@ -559,12 +576,12 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: eppRolebindingName(modelService), Namespace: namespace}, &rolebinding)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.EPPRoleBindingName(modelService), Namespace: namespace}, &rolebinding)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
 			By("Checking if epp RoleBinding has correct owner reference")
-			Expect(rolebinding.Name).To(Equal(eppRolebindingName(modelService)))
+			Expect(rolebinding.Name).To(Equal(render.EPPRoleBindingName(modelService)))
 			Expect(rolebinding.OwnerReferences).ToNot(BeEmpty())
 
 			By("Checking if ModelService status has been updated")
@@ -615,10 +632,43 @@ var _ = Describe("ModelService Controller", func() {
 				return true
 			}, time.Second*10, time.Millisecond*500).Should(BeTrue())
 
-			By("Validating that the HTTPRoute was created")
+			By("Checking that the HTTPRoute waits for the EPP to be available")
 			httpRoute := gatewayv1.HTTPRoute{}
+			err = k8sClient.Get(ctx, client.ObjectKey{Name: render.HTTPRouteName(modelService), Namespace: namespace}, &httpRoute)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			updatedMSVC := &msv1alpha1.ModelService{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedMSVC)).To(Succeed())
+			routeCondition := meta.FindStatusCondition(updatedMSVC.Status.Conditions, httpRouteAttachedCondition)
+			Expect(routeCondition).NotTo(BeNil())
+			Expect(routeCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(routeCondition.Reason).To(Equal(waitingForEPPReason))
+
+			By("Making the EPP available")
+			eppDeployment := appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: render.EPPDeploymentName(modelService), Namespace: namespace}, &eppDeployment)).To(Succeed())
+			eppDeployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"}}
+			Expect(k8sClient.Status().Update(ctx, &eppDeployment)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedMSVC)).To(Succeed())
+			routeCondition = meta.FindStatusCondition(updatedMSVC.Status.Conditions, httpRouteAttachedCondition)
+			Expect(routeCondition).NotTo(BeNil())
+			Expect(routeCondition.Reason).To(Equal(waitingForEndpointsReason))
+
+			By("Making a decode replica ready")
+			decodeDeployment := appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: decodeWorkloadName, Namespace: namespace}, &decodeDeployment)).To(Succeed())
+			decodeDeployment.Status.Replicas = 1
+			decodeDeployment.Status.ReadyReplicas = 1
+			Expect(k8sClient.Status().Update(ctx, &decodeDeployment)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Validating that the HTTPRoute was created")
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: httpRouteName(modelService), Namespace: namespace}, &httpRoute)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.HTTPRouteName(modelService), Namespace: namespace}, &httpRoute)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(httpRoute.OwnerReferences).ToNot(BeEmpty())
@@ -635,14 +685,14 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: httpRouteName(modelService), Namespace: namespace}, &httpRoute)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.HTTPRouteName(modelService), Namespace: namespace}, &httpRoute)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
 			By("Validating that the inferencepool was created")
 			infPool := giev1alpha2.InferencePool{}
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: infPoolName(modelService), Namespace: namespace}, &infPool)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.InferencePoolName(modelService), Namespace: namespace}, &infPool)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(infPool.OwnerReferences).ToNot(BeEmpty())
@@ -659,14 +709,14 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: infPoolName(modelService), Namespace: namespace}, &infPool)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.InferencePoolName(modelService), Namespace: namespace}, &infPool)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 
 			By("Validating that the inferencemodel was created")
 			infModel := giev1alpha2.InferenceModel{}
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: infModelName(modelService), Namespace: namespace}, &infModel)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.InferenceModelName(modelService), Namespace: namespace}, &infModel)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(infModel.OwnerReferences).ToNot(BeEmpty())
@@ -683,7 +733,7 @@ var _ = Describe("ModelService Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: infModelName(modelService), Namespace: namespace}, &infModel)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.InferenceModelName(modelService), Namespace: namespace}, &infModel)
 				return err == nil
 			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
 		})
@@ -753,7 +803,7 @@ var _ = Describe("ModelService Controller", func() {
 			reconciler := &ModelServiceReconciler{
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
-				RBACOptions: RBACOptions{
+				RBACOptions: render.RBACOptions{
 					EPPPullSecrets: []string{"epp-pull-secret"},
 					PDPullSecrets:  []string{"secret1", "secret2"},
 					EPPClusterRole: "epp-cluster-role",
@@ -771,13 +821,13 @@ var _ = Describe("ModelService Controller", func() {
 			var decodeDeployment, prefillDeployment appsv1.Deployment
 
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: deploymentName(secondModelService, "decode"), Namespace: namespace}, &decodeDeployment)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.DeploymentName(secondModelService, "decode"), Namespace: namespace}, &decodeDeployment)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(decodeDeployment.Spec.Replicas).Should(Equal(&replicas))
 
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: deploymentName(secondModelService, "prefill"), Namespace: namespace}, &prefillDeployment)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.DeploymentName(secondModelService, "prefill"), Namespace: namespace}, &prefillDeployment)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(prefillDeployment.Spec.Replicas).Should(Equal(&replicas))
@@ -805,13 +855,13 @@ var _ = Describe("ModelService Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: deploymentName(secondModelService, "decode"), Namespace: namespace}, &decodeDeployment)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.DeploymentName(secondModelService, "decode"), Namespace: namespace}, &decodeDeployment)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(decodeDeployment.Spec.Replicas).Should(Equal(&replicas))
 
 			Eventually(func() bool {
-				err := k8sClient.Get(ctx, client.ObjectKey{Name: deploymentName(secondModelService, "prefill"), Namespace: namespace}, &prefillDeployment)
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: render.DeploymentName(secondModelService, "prefill"), Namespace: namespace}, &prefillDeployment)
 				return err == nil
 			}, time.Second*5, time.Millisecond*500).Should(BeTrue())
 			Expect(prefillDeployment.Spec.Replicas).Should(Equal(&replicas))
@@ -825,7 +875,7 @@ var _ = Describe("ModelService Controller", func() {
 			hfMSVCName := "hf-msvc"
 			hfRepo := "repo"
 			hfModel := "model-id"
-			hfURI := HF_PREFIX + pathSep + hfRepo + hfModel
+			hfURI := render.MODEL_ARTIFACT_URI_HF_PREFIX + "/" + hfRepo + hfModel
 
 			hfNamespacedName := types.NamespacedName{
 				Name:      hfMSVCName,
@@ -893,7 +943,7 @@ var _ = Describe("ModelService Controller", func() {
 			By("fetching the decode deployment child resource")
 			// fetch decode resource name
 			decodeNamespacedName := types.NamespacedName{
-				Name:      deploymentName(hfMSVC, DECODE_ROLE),
+				Name:      render.DeploymentName(hfMSVC, render.DECODE_ROLE),
 				Namespace: namespace,
 			}
 
@@ -907,23 +957,408 @@ var _ = Describe("ModelService Controller", func() {
 
 			By("checking decode child has an empty dir volume")
 			Expect(len(decode.Spec.Template.Spec.Volumes)).To(Equal(1))
-			Expect(decode.Spec.Template.Spec.Volumes[0].Name).To(Equal(modelStorageVolumeName))
+			Expect(decode.Spec.Template.Spec.Volumes[0].Name).To(Equal(render.ModelStorageVolumeName))
 			Expect(decode.Spec.Template.Spec.Volumes[0].EmptyDir).To(Not(BeNil()))
 
 			By("checking hte decode container where mountModelVolume is true has a volume mount")
 			Expect(len(decode.Spec.Template.Spec.Containers)).To(Equal(1))
 			Expect(len(decode.Spec.Template.Spec.Containers[0].VolumeMounts)).To(Equal(1))
-			Expect(decode.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name).To(Equal(modelStorageVolumeName))
-			Expect(decode.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath).To(Equal(modelStorageRoot))
+			Expect(decode.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name).To(Equal(render.ModelStorageVolumeName))
+			Expect(decode.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath).To(Equal(render.ModelStorageRoot))
 
 			By("checking decode has 1 env for HF_HOME and none for HF_TOKEN because authSecretName is not provided")
 			Expect(len(decode.Spec.Template.Spec.Containers[0].Env)).To(Equal(1))
-			Expect(decode.Spec.Template.Spec.Containers[0].Env[0].Name).To(Equal(ENV_HF_HOME))
-			Expect(decode.Spec.Template.Spec.Containers[0].Env[0].Value).To(Equal(modelStorageRoot))
+			Expect(decode.Spec.Template.Spec.Containers[0].Env[0].Name).To(Equal(render.ENV_HF_HOME))
+			Expect(decode.Spec.Template.Spec.Containers[0].Env[0].Value).To(Equal(render.ModelStorageRoot))
 
 			By("checking decode container args are interpolated")
 			Expect(len(decode.Spec.Template.Spec.Containers[0].Args)).To(Equal(1))
-			Expect(decode.Spec.Template.Spec.Containers[0].Args[0]).To(Equal(modelStorageRoot))
+			Expect(decode.Spec.Template.Spec.Containers[0].Args[0]).To(Equal(render.ModelStorageRoot))
+		})
+	})
+
+	Context("When reconciling a ModelService caching its model", func() {
+		It("should create the deployments once the model is downloaded", func() {
+			cachedMSVC := &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cached-msvc",
+					Namespace: namespace,
+				},
+				Spec: msv1alpha1.ModelServiceSpec{
+					ModelArtifacts: msv1alpha1.ModelArtifacts{
+						URI:   "hf://facebook/opt-125m",
+						Size:  ptr.To(resource.MustParse("1Gi")),
+						Cache: &msv1alpha1.ModelCache{},
+					},
+					Routing: msv1alpha1.Routing{
+						ModelName: "facebook/opt-125m",
+					},
+					Decode: &msv1alpha1.PDSpec{
+						ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+							Replicas: ptr.To[int32](1),
+							Containers: []msv1alpha1.ContainerSpec{
+								{
+									Name:             "llm",
+									Image:            &imageName,
+									MountModelVolume: true,
+								},
+							},
+						},
+					},
+				},
+			}
+			cachedNamespacedName := client.ObjectKeyFromObject(cachedMSVC)
+			Expect(k8sClient.Create(ctx, cachedMSVC)).To(Succeed())
+
+			reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: cachedNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("checking the cache and the download job are created")
+			var pvc corev1.PersistentVolumeClaim
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: render.ModelCachePVCName(cachedMSVC), Namespace: namespace}, &pvc)).To(Succeed())
+			Expect(pvc.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}))
+			Expect(pvc.OwnerReferences).ToNot(BeEmpty())
+			var job batchv1.Job
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: render.ModelDownloadJobName(cachedMSVC), Namespace: namespace}, &job)).To(Succeed())
+			Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal(imageName))
+
+			By("checking the decode deployment waits for the download")
+			decodeKey := client.ObjectKey{Name: render.DeploymentName(cachedMSVC, render.DECODE_ROLE), Namespace: namespace}
+			err = k8sClient.Get(ctx, decodeKey, &appsv1.Deployment{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			updatedMSVC := &msv1alpha1.ModelService{}
+			Expect(k8sClient.Get(ctx, cachedNamespacedName, updatedMSVC)).To(Succeed())
+			artifactsCondition := meta.FindStatusCondition(updatedMSVC.Status.Conditions, artifactsReadyCondition)
+			Expect(artifactsCondition).NotTo(BeNil())
+			Expect(artifactsCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(artifactsCondition.Reason).To(Equal(downloadingReason))
+
+			By("completing the download job")
+			now := metav1.Now()
+			job.Status.StartTime = &now
+			job.Status.CompletionTime = &now
+			job.Status.Succeeded = 1
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue, LastTransitionTime: now},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: now},
+			}
+			Expect(k8sClient.Status().Update(ctx, &job)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: cachedNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("checking the decode deployment mounts the cache read-only")
+			var decode appsv1.Deployment
+			Eventually(func() error {
+				return k8sClient.Get(ctx, decodeKey, &decode)
+			}, time.Second*5, time.Millisecond*500).Should(Succeed())
+			Expect(decode.Spec.Template.Spec.Volumes).To(HaveLen(1))
+			Expect(decode.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim).To(Equal(&corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: render.ModelCachePVCName(cachedMSVC),
+				ReadOnly:  true,
+			}))
+			Expect(k8sClient.Get(ctx, cachedNamespacedName, updatedMSVC)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updatedMSVC.Status.Conditions, artifactsReadyCondition)).To(BeTrue())
+		})
+	})
+
+	Context("When reconciling a ModelService reading its model from a PersistentVolumeClaim", func() {
+		It("should report whether the claim is bound", func() {
+			claimMSVC := &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "claim-msvc",
+					Namespace: namespace,
+				},
+				Spec: msv1alpha1.ModelServiceSpec{
+					ModelArtifacts: msv1alpha1.ModelArtifacts{
+						URI:           "pvc://shared-models/granite/3.3",
+						WritableCache: &msv1alpha1.WritableCache{},
+					},
+					Routing: msv1alpha1.Routing{
+						ModelName: "granite",
+					},
+					Decode: &msv1alpha1.PDSpec{
+						ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+							Replicas: ptr.To[int32](1),
+							Containers: []msv1alpha1.ContainerSpec{
+								{
+									Name:             "llm",
+									Image:            &imageName,
+									MountModelVolume: true,
+								},
+							},
+						},
+					},
+				},
+			}
+			claimNamespacedName := client.ObjectKeyFromObject(claimMSVC)
+			Expect(k8sClient.Create(ctx, claimMSVC)).To(Succeed())
+
+			reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("checking the missing claim is reported without holding the deployment")
+			updatedMSVC := &msv1alpha1.ModelService{}
+			Expect(k8sClient.Get(ctx, claimNamespacedName, updatedMSVC)).To(Succeed())
+			artifactsCondition := meta.FindStatusCondition(updatedMSVC.Status.Conditions, artifactsReadyCondition)
+			Expect(artifactsCondition).NotTo(BeNil())
+			Expect(artifactsCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(artifactsCondition.Reason).To(Equal(claimNotFoundReason))
+
+			By("checking the decode container only mounts the model directory, and a writable cache")
+			var decode appsv1.Deployment
+			decodeKey := client.ObjectKey{Name: render.DeploymentName(claimMSVC, render.DECODE_ROLE), Namespace: namespace}
+			Expect(k8sClient.Get(ctx, decodeKey, &decode)).To(Succeed())
+			Expect(decode.Spec.Template.Spec.Volumes).To(HaveLen(2))
+			Expect(decode.Spec.Template.Spec.Volumes[1].Name).To(Equal(render.WritableCacheVolumeName))
+			Expect(decode.Spec.Template.Spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: render.ModelStorageVolumeName, MountPath: render.ModelStorageRoot + "/granite/3.3", SubPath: "granite/3.3", ReadOnly: true},
+				{Name: render.WritableCacheVolumeName, MountPath: render.WritableCacheRoot},
+			}))
+
+			By("binding the claim")
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-models", Namespace: namespace},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, pvc)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, claimNamespacedName, updatedMSVC)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedMSVC.Status.Conditions, artifactsReadyCondition).Reason).To(Equal(claimNotBoundReason))
+
+			pvc.Status.Phase = corev1.ClaimBound
+			Expect(k8sClient.Status().Update(ctx, pvc)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, claimNamespacedName, updatedMSVC)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updatedMSVC.Status.Conditions, artifactsReadyCondition)).To(BeTrue())
+		})
+	})
+
+	Context("When reconciling a ModelService verifying its oci:// model", func() {
+		It("should hold the deployment until the image resolves to the approved digest", func() {
+			By("pushing the model image to a local registry")
+			var manifestHeads atomic.Int32
+			handler := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodHead && strings.Contains(req.URL.Path, "/manifests/") {
+					manifestHeads.Add(1)
+				}
+				handler.ServeHTTP(w, req)
+			}))
+			defer server.Close()
+			reference := strings.TrimPrefix(server.URL, "http://") + "/models/granite:3.3"
+			image, err := random.Image(1024, 1)
+			Expect(err).NotTo(HaveOccurred())
+			tag, err := name.ParseReference(reference)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remote.Write(tag, image)).To(Succeed())
+			pushHeads := manifestHeads.Load()
+			digest, err := image.Digest()
+			Expect(err).NotTo(HaveOccurred())
+
+			verifiedMSVC := &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "verified-msvc",
+					Namespace: namespace,
+				},
+				Spec: msv1alpha1.ModelServiceSpec{
+					ModelArtifacts: msv1alpha1.ModelArtifacts{
+						URI: "oci://" + reference,
+						Verification: &msv1alpha1.ModelVerification{
+							Digest: "sha256:" + strings.Repeat("0", 64),
+						},
+					},
+					Routing: msv1alpha1.Routing{
+						ModelName: "granite",
+					},
+					Decode: &msv1alpha1.PDSpec{
+						ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+							Replicas: ptr.To[int32](1),
+							Containers: []msv1alpha1.ContainerSpec{
+								{
+									Name:             "llm",
+									Image:            &imageName,
+									MountModelVolume: true,
+								},
+							},
+						},
+					},
+				},
+			}
+			verifiedNamespacedName := client.ObjectKeyFromObject(verifiedMSVC)
+			Expect(k8sClient.Create(ctx, verifiedMSVC)).To(Succeed())
+
+			reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: verifiedNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("checking a digest mismatch holds the decode deployment")
+			updatedMSVC := &msv1alpha1.ModelService{}
+			Expect(k8sClient.Get(ctx, verifiedNamespacedName, updatedMSVC)).To(Succeed())
+			verifiedCondition := meta.FindStatusCondition(updatedMSVC.Status.Conditions, artifactsVerifiedCondition)
+			Expect(verifiedCondition).NotTo(BeNil())
+			Expect(verifiedCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(verifiedCondition.Reason).To(Equal(digestMismatchReason))
+			Expect(verifiedCondition.Message).To(ContainSubstring(digest.String()))
+			Expect(updatedMSVC.Status.VerifiedDigest).To(BeEmpty())
+
+			decodeKey := client.ObjectKey{Name: render.DeploymentName(verifiedMSVC, render.DECODE_ROLE), Namespace: namespace}
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, decodeKey, &appsv1.Deployment{}))).To(BeTrue())
+
+			By("approving the digest of the image")
+			updatedMSVC.Spec.ModelArtifacts.Verification.Digest = digest.String()
+			Expect(k8sClient.Update(ctx, updatedMSVC)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: verifiedNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, verifiedNamespacedName, updatedMSVC)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updatedMSVC.Status.Conditions, artifactsVerifiedCondition)).To(BeTrue())
+			Expect(updatedMSVC.Status.VerifiedDigest).To(Equal(digest.String()))
+
+			By("reusing the digest resolved in the registry")
+			Expect(manifestHeads.Load() - pushHeads).To(Equal(int32(1)))
+
+			By("checking the image volume is pulled by digest")
+			var decode appsv1.Deployment
+			Expect(k8sClient.Get(ctx, decodeKey, &decode)).To(Succeed())
+			Expect(decode.Spec.Template.Spec.Volumes[0].Image.Reference).To(Equal(strings.TrimSuffix(reference, ":3.3") + "@" + digest.String()))
+		})
+	})
+
+	Context("When the registry of a verified oci:// model is unavailable", func() {
+		It("should wait and check the registry again after a bounded delay", func() {
+			server := httptest.NewServer(http.NotFoundHandler())
+			reference := strings.TrimPrefix(server.URL, "http://") + "/models/granite:3.3"
+			server.Close()
+
+			unavailableMSVC := &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unavailable-registry-msvc",
+					Namespace: namespace,
+				},
+				Spec: msv1alpha1.ModelServiceSpec{
+					ModelArtifacts: msv1alpha1.ModelArtifacts{
+						URI: "oci://" + reference,
+						Verification: &msv1alpha1.ModelVerification{
+							Digest: "sha256:" + strings.Repeat("0", 64),
+						},
+					},
+					Routing: msv1alpha1.Routing{
+						ModelName: "granite",
+					},
+				},
+			}
+			unavailableNamespacedName := client.ObjectKeyFromObject(unavailableMSVC)
+			Expect(k8sClient.Create(ctx, unavailableMSVC)).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, unavailableMSVC)).To(Succeed()) }()
+
+			reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: unavailableNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(registryPollInterval))
+
+			updatedMSVC := &msv1alpha1.ModelService{}
+			Expect(k8sClient.Get(ctx, unavailableNamespacedName, updatedMSVC)).To(Succeed())
+			verifiedCondition := meta.FindStatusCondition(updatedMSVC.Status.Conditions, artifactsVerifiedCondition)
+			Expect(verifiedCondition).NotTo(BeNil())
+			Expect(verifiedCondition.Reason).To(Equal(registryUnavailableReason))
+		})
+	})
+
+	Context("When reconciling a ModelService reading its model with an authentication Secret", func() {
+		It("should hold the deployment until the Secret has the token", func() {
+			authMSVC := &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "auth-msvc",
+					Namespace: namespace,
+				},
+				Spec: msv1alpha1.ModelServiceSpec{
+					ModelArtifacts: msv1alpha1.ModelArtifacts{
+						URI:            "hf://meta-llama/Llama-3.1-8B",
+						AuthSecretName: ptr.To("gated-models"),
+						HFToken: &msv1alpha1.HFToken{
+							SecretKey: "llama",
+							Injection: msv1alpha1.HFTokenInjectionDownloadOnly,
+						},
+						Download: &msv1alpha1.ModelDownload{},
+					},
+					Routing: msv1alpha1.Routing{
+						ModelName: "llama",
+					},
+					Decode: &msv1alpha1.PDSpec{
+						ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+							Replicas: ptr.To[int32](1),
+							Containers: []msv1alpha1.ContainerSpec{
+								{
+									Name:             "llm",
+									Image:            &imageName,
+									MountModelVolume: true,
+								},
+							},
+						},
+					},
+				},
+			}
+			authNamespacedName := client.ObjectKeyFromObject(authMSVC)
+			Expect(k8sClient.Create(ctx, authMSVC)).To(Succeed())
+
+			reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: authNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(authSecretPollInterval))
+
+			By("checking the missing Secret holds the decode deployment")
+			updatedMSVC := &msv1alpha1.ModelService{}
+			Expect(k8sClient.Get(ctx, authNamespacedName, updatedMSVC)).To(Succeed())
+			secretCondition := meta.FindStatusCondition(updatedMSVC.Status.Conditions, authSecretReadyCondition)
+			Expect(secretCondition).NotTo(BeNil())
+			Expect(secretCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(secretCondition.Reason).To(Equal(secretNotFoundReason))
+
+			decodeKey := client.ObjectKey{Name: render.DeploymentName(authMSVC, render.DECODE_ROLE), Namespace: namespace}
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, decodeKey, &appsv1.Deployment{}))).To(BeTrue())
+
+			By("creating the Secret without the token key")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gated-models", Namespace: namespace},
+				Data:       map[string][]byte{"HF_TOKEN": []byte("hf_token")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: authNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, authNamespacedName, updatedMSVC)).To(Succeed())
+			secretCondition = meta.FindStatusCondition(updatedMSVC.Status.Conditions, authSecretReadyCondition)
+			Expect(secretCondition.Reason).To(Equal(secretKeyMissingReason))
+			Expect(secretCondition.Message).To(ContainSubstring("llama"))
+
+			By("adding the token key")
+			secret.Data["llama"] = []byte("hf_token")
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: authNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(k8sClient.Get(ctx, authNamespacedName, updatedMSVC)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updatedMSVC.Status.Conditions, authSecretReadyCondition)).To(BeTrue())
+
+			By("checking only the download init container gets the token")
+			var decode appsv1.Deployment
+			Expect(k8sClient.Get(ctx, decodeKey, &decode)).To(Succeed())
+			downloadContainer := decode.Spec.Template.Spec.InitContainers[0]
+			Expect(downloadContainer.Name).To(Equal(render.MODEL_DOWNLOAD_CONTAINER_NAME))
+			Expect(downloadContainer.Env[0].Name).To(Equal(render.ENV_HF_TOKEN))
+			Expect(downloadContainer.Env[0].ValueFrom.SecretKeyRef.Key).To(Equal("llama"))
+			for _, env := range decode.Spec.Template.Spec.Containers[0].Env {
+				Expect(env.Name).NotTo(Equal(render.ENV_HF_TOKEN))
+			}
 		})
 	})
 
@@ -933,7 +1368,7 @@ var _ = Describe("ModelService Controller", func() {
 				ctx := context.Background()
 
 				// Set RBAC options with EPPPullSecrets and PDPullSecrets
-				rbacOptions = &RBACOptions{
+				rbacOptions = &render.RBACOptions{
 					EPPPullSecrets: []string{"epp-pull-secret"},
 					PDPullSecrets:  []string{"secret1", "secret2"},
 					EPPClusterRole: "epp-cluster-role",
@@ -996,3 +1431,112 @@ var _ = Describe("ModelService Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("newBaseConfigCondition", func() {
+	It("should be true for a valid base config", func() {
+		condition := newBaseConfigCondition(&corev1.ConfigMap{Data: map[string]string{"decodeDeployment": "spec: {}"}}, nil)
+		Expect(condition.Type).To(Equal(baseConfigValidCondition))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Valid"))
+	})
+
+	It("should warn about unknown keys", func() {
+		condition := newBaseConfigCondition(&corev1.ConfigMap{Data: map[string]string{"prefilDeployment": "spec: {}"}}, nil)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("UnknownKeys"))
+		Expect(condition.Message).To(ContainSubstring("prefilDeployment"))
+	})
+
+	It("should report every decode error", func() {
+		baseConfigMap := &corev1.ConfigMap{Data: map[string]string{
+			"decodeDeployment": "spec:\n  replica: 1\n",
+			"eppService":       "spec:\n  typ: ClusterIP\n",
+		}}
+		_, err := render.BaseConfigFromCM(baseConfigMap)
+		Expect(err).To(HaveOccurred())
+
+		condition := newBaseConfigCondition(baseConfigMap, err)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("DecodeFailed"))
+		Expect(condition.Message).To(ContainSubstring("decodeDeployment (line 2)"))
+		Expect(condition.Message).To(ContainSubstring("eppService (line 2)"))
+	})
+
+	It("should report missing template variables", func() {
+		baseConfigMap := &corev1.ConfigMap{Data: map[string]string{
+			"templateVars": "team:\n  required: true\n",
+		}}
+		msvc := &msv1alpha1.ModelService{}
+		_, err := render.ResolveTemplateVars([]render.BaseConfigLayer{{ConfigMap: baseConfigMap}}, msvc)
+		Expect(err).To(HaveOccurred())
+
+		condition := newTemplateVarsCondition(msvc, err)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Type).To(Equal(templateVarsResolvedCondition))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("MissingTemplateVars"))
+		Expect(condition.Message).To(ContainSubstring("team"))
+
+		By("reporting the condition resolved once the variable is set")
+		msvc.Status.Conditions = []metav1.Condition{*condition}
+		resolved := newTemplateVarsCondition(msvc, nil)
+		Expect(resolved).NotTo(BeNil())
+		Expect(resolved.Status).To(Equal(metav1.ConditionTrue))
+
+		By("not reporting the condition for ModelServices that never missed a variable")
+		Expect(newTemplateVarsCondition(&msv1alpha1.ModelService{}, nil)).To(BeNil())
+	})
+})
+
+var _ = Describe("Requeue policy", func() {
+	It("should wait for a missing base config without an error", func() {
+		ctx := context.Background()
+		msvc := &msv1alpha1.ModelService{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing-base-config", Namespace: namespace},
+			Spec: msv1alpha1.ModelServiceSpec{
+				BaseConfigMapRef: &corev1.ObjectReference{Name: "not-created-yet"},
+				Routing:          msv1alpha1.Routing{ModelName: "model"},
+				ModelArtifacts:   msv1alpha1.ModelArtifacts{URI: "hf://org/model"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, msvc)).To(Succeed())
+		defer func() { Expect(k8sClient.Delete(ctx, msvc)).To(Succeed()) }()
+
+		reconciler := &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(msvc)})
+		Expect(err).NotTo(HaveOccurred())
+		// the missing base config is recorded in the chain; its creation triggers a reconcile
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(msvc), msvc)).To(Succeed())
+		condition := meta.FindStatusCondition(msvc.Status.Conditions, baseConfigValidCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("NotFound"))
+		Expect(msvc.Status.BaseConfigChain).To(ConsistOf(msv1alpha1.BaseConfigLayerReference{
+			Kind:      msv1alpha1.ConfigMapKind,
+			Namespace: namespace,
+			Name:      "not-created-yet",
+		}))
+	})
+
+	It("should not retry permanent errors", func() {
+		Expect(isPermanentError(&render.CycleError{Chain: []string{"a", "b", "a"}})).To(BeTrue())
+		Expect(isPermanentError(fmt.Errorf("parent: %w", &ReferenceNotPermittedError{}))).To(BeTrue())
+		Expect(isPermanentError(errors.NewInvalid(appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind(), "decode", nil))).To(BeTrue())
+		Expect(isPermanentError(errors.NewServiceUnavailable("etcd leader changed"))).To(BeFalse())
+	})
+
+	It("should index ModelServices by the base config they reference", func() {
+		msvc := &msv1alpha1.ModelService{
+			ObjectMeta: metav1.ObjectMeta{Name: "indexed", Namespace: namespace},
+			Spec:       msv1alpha1.ModelServiceSpec{BaseConfigMapRef: &corev1.ObjectReference{Name: "shared"}},
+		}
+		Expect(indexBaseConfigRef(msvc)).To(Equal([]string{"ConfigMap/default/shared"}))
+
+		msvc.Spec.BaseConfigMapRef = &corev1.ObjectReference{Kind: msv1alpha1.ClusterModelServiceBaseConfigKind, Name: "shared", Namespace: "ignored"}
+		Expect(indexBaseConfigRef(msvc)).To(Equal([]string{"ClusterModelServiceBaseConfig//shared"}))
+
+		msvc.Spec.BaseConfigMapRef = nil
+		Expect(indexBaseConfigRef(msvc)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readyCondition reports whether every role deployed by a ModelService has all its replicas ready
+const readyCondition = "Ready"
+
+// baseConfigPropagatedCondition reports whether the current revision of the
+// base config has been rolled out to a ModelService
+const baseConfigPropagatedCondition = "BaseConfigPropagated"
+
+// Reasons of the BaseConfigPropagated condition
+const (
+	propagatedReason = "Propagated"
+	rollingReason    = "Rolling"
+	waitingReason    = "Waiting"
+	haltedReason     = "Halted"
+	failedReason     = "Failed"
+)
+
+// propagationPollInterval is how often a ModelService waiting for the
+// other dependents of its base config checks whether it may roll out
+const propagationPollInterval = 15 * time.Second
+
+// progressDeadlineExceededReason is the reason of the Progressing condition
+// of a deployment that failed to roll out
+const progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+// propagationDecision decides whether msvc may roll out revision of its base config,
+// given the policy and the ModelServices inheriting from the base config declaring it,
+// including msvc. Dependents rolling out the current revision of their own chain count
+// against the policy: revisions holds those revisions, and a dependent missing from it
+// shares revision with msvc. It returns the reason and message of the
+// BaseConfigPropagated condition of msvc if it must wait
+func propagationDecision(msvc *msv1alpha1.ModelService, policy *msv1alpha1.PropagationPolicy, dependents []msv1alpha1.ModelService, revision string, revisions map[types.NamespacedName]string) (admit bool, reason, message string, err error) {
+	// nothing to restart, or the rollout has already started
+	if policy == nil || msvc.Status.BaseConfigRevision == "" || msvc.Status.BaseConfigRevision == revision {
+		return true, "", "", nil
+	}
+	// changes of the ModelService itself are not held back
+	if msvc.Generation != msvc.Status.ObservedGeneration {
+		return true, "", "", nil
+	}
+
+	limit, err := intstr.GetScaledValueFromIntOrPercent(&policy.MaxRolling, len(dependents), true)
+	if err != nil {
+		return false, "", "", err
+	}
+	limit = max(limit, 1)
+
+	rolling := 0
+	for i := range dependents {
+		dependent := &dependents[i]
+		if dependent.Namespace == msvc.Namespace && dependent.Name == msvc.Name {
+			continue
+		}
+		current, ok := revisions[client.ObjectKeyFromObject(dependent)]
+		if !ok {
+			current = revision
+		}
+		if dependent.Status.BaseConfigRevision != current || !dependent.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if meta.IsStatusConditionPresentAndEqual(dependent.Status.Conditions, readyCondition, metav1.ConditionTrue) {
+			continue
+		}
+		if propagated := meta.FindStatusCondition(dependent.Status.Conditions, baseConfigPropagatedCondition); propagated != nil && propagated.Reason == failedReason {
+			return false, haltedReason, fmt.Sprintf("ModelService %s/%s failed to roll out base config revision %s", dependent.Namespace, dependent.Name, current), nil
+		}
+		rolling++
+	}
+
+	if rolling >= limit {
+		return false, waitingReason, fmt.Sprintf("%d of %d ModelServices inheriting from the base config are rolling out a new revision, at most %d at once", rolling, len(dependents), limit), nil
+	}
+	return true, "", "", nil
+}
+
+// admitBaseConfigRevision decides whether msvc may roll out revision of the base config chain,
+// recorded in its status. It returns the BaseConfigPropagated condition to report if it must wait
+func (r *ModelServiceReconciler) admitBaseConfigRevision(ctx context.Context, msvc *msv1alpha1.ModelService, chain []render.BaseConfigLayer, revision string) (bool, *metav1.Condition, error) {
+	policy, layer, err := render.ChainPropagation(chain)
+	if err != nil || policy == nil {
+		return true, nil, err
+	}
+
+	// the policy applies to every ModelService inheriting from the base config declaring it
+	owner := msvc.Status.BaseConfigChain[layer]
+	dependents, err := modelServicesIndexedBy(ctx, r.Client, baseConfigChainIndex, owner.Kind, types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name})
+	if err != nil {
+		return false, nil, err
+	}
+	revisions, err := r.dependentRevisions(ctx, msvc, dependents)
+	if err != nil {
+		return false, nil, err
+	}
+
+	admit, reason, message, err := propagationDecision(msvc, policy, dependents, revision, revisions)
+	if err != nil || admit {
+		return admit, nil, err
+	}
+	return false, &metav1.Condition{
+		Type:    baseConfigPropagatedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}, nil
+}
+
+// dependentRevisions returns the current revision of the base config chain of the
+// dependents that may be rolling out, that is not ready and not referencing the
+// base config of msvc. A dependent whose chain cannot be resolved is not rolling out
+func (r *ModelServiceReconciler) dependentRevisions(ctx context.Context, msvc *msv1alpha1.ModelService, dependents []msv1alpha1.ModelService) (map[types.NamespacedName]string, error) {
+	revisions := map[types.NamespacedName]string{}
+	ref := indexBaseConfigRef(msvc)
+	for i := range dependents {
+		dependent := &dependents[i]
+		if slices.Equal(indexBaseConfigRef(dependent), ref) ||
+			meta.IsStatusConditionPresentAndEqual(dependent.Status.Conditions, readyCondition, metav1.ConditionTrue) {
+			continue
+		}
+		chain, _, err := r.getBaseConfigChain(ctx, dependent)
+		if err != nil {
+			if errors.IsNotFound(err) || isPermanentError(err) {
+				revisions[client.ObjectKeyFromObject(dependent)] = ""
+				continue
+			}
+			return nil, err
+		}
+		revisions[client.ObjectKeyFromObject(dependent)] = render.BaseConfigRevision(chain)
+	}
+	return revisions, nil
+}
+
+// newReadyCondition returns the Ready condition of msvc from its READY statuses
+func newReadyCondition(msvc *msv1alpha1.ModelService) metav1.Condition {
+	if modelServiceState(msvc) == readyState {
+		return metav1.Condition{
+			Type:    readyCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Ready",
+			Message: "all replicas are ready",
+		}
+	}
+	return metav1.Condition{
+		Type:    readyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NotReady",
+		Message: "waiting for replicas to be ready",
+	}
+}
+
+// newPropagatedCondition returns the BaseConfigPropagated condition of msvc,
+// once the child resources of revision have been applied
+func newPropagatedCondition(msvc *msv1alpha1.ModelService, revision string) metav1.Condition {
+	if meta.IsStatusConditionTrue(msvc.Status.Conditions, readyCondition) {
+		return metav1.Condition{
+			Type:    baseConfigPropagatedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  propagatedReason,
+			Message: fmt.Sprintf("base config revision %s is ready", revision),
+		}
+	}
+	for _, conditionType := range []string{"PrefillProgressing", "DecodeProgressing"} {
+		if c := meta.FindStatusCondition(msvc.Status.Conditions, conditionType); c != nil && c.Reason == progressDeadlineExceededReason {
+			return metav1.Condition{
+				Type:    baseConfigPropagatedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  failedReason,
+				Message: fmt.Sprintf("base config revision %s failed to roll out: %s", revision, c.Message),
+			}
+		}
+	}
+	return metav1.Condition{
+		Type:    baseConfigPropagatedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  rollingReason,
+		Message: fmt.Sprintf("rolling out base config revision %s", revision),
+	}
+}
+
+// recordPropagationEvent emits an Event when the BaseConfigPropagated condition
+// of msvc changes from previous to current
+func (r *ModelServiceReconciler) recordPropagationEvent(msvc *msv1alpha1.ModelService, previous []metav1.Condition, current metav1.Condition) {
+	if r.Recorder == nil {
+		return
+	}
+	if existing := meta.FindStatusCondition(previous, current.Type); existing != nil && existing.Reason == current.Reason {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if current.Reason == haltedReason || current.Reason == failedReason {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Event(msvc, eventType, "BaseConfig"+current.Reason, current.Message)
+}
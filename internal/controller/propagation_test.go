@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/render"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Base config propagation", func() {
+	const (
+		oldRevision = "0123456789abcdef"
+		newRevision = "fedcba9876543210"
+	)
+
+	dependent := func(name, revision string, conditions ...metav1.Condition) msv1alpha1.ModelService {
+		return msv1alpha1.ModelService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1},
+			Status: msv1alpha1.ModelServiceStatus{
+				ObservedGeneration: 1,
+				BaseConfigRevision: revision,
+				Conditions:         conditions,
+			},
+		}
+	}
+	ready := metav1.Condition{Type: readyCondition, Status: metav1.ConditionTrue}
+	notReady := metav1.Condition{Type: readyCondition, Status: metav1.ConditionFalse}
+	failed := metav1.Condition{Type: baseConfigPropagatedCondition, Status: metav1.ConditionFalse, Reason: failedReason}
+
+	policy := func(maxRolling intstr.IntOrString) *msv1alpha1.PropagationPolicy {
+		return &msv1alpha1.PropagationPolicy{MaxRolling: maxRolling}
+	}
+
+	It("should roll out without a policy, to new ModelServices and on spec changes", func() {
+		msvc := dependent("a", oldRevision)
+		dependents := []msv1alpha1.ModelService{msvc, dependent("b", newRevision, notReady)}
+
+		admit, _, _, err := propagationDecision(&msvc, nil, dependents, newRevision, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeTrue())
+
+		created := dependent("c", "")
+		admit, _, _, _ = propagationDecision(&created, policy(intstr.FromInt32(1)), dependents, newRevision, nil)
+		Expect(admit).To(BeTrue())
+
+		msvc.Generation = 2
+		admit, _, _, _ = propagationDecision(&msvc, policy(intstr.FromInt32(1)), dependents, newRevision, nil)
+		Expect(admit).To(BeTrue())
+	})
+
+	It("should wait while the other dependents are rolling out", func() {
+		msvc := dependent("a", oldRevision)
+		dependents := []msv1alpha1.ModelService{
+			msvc,
+			dependent("b", newRevision, notReady),
+			dependent("c", newRevision, ready),
+			dependent("d", oldRevision, ready),
+		}
+
+		admit, reason, message, err := propagationDecision(&msvc, policy(intstr.FromInt32(1)), dependents, newRevision, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeFalse())
+		Expect(reason).To(Equal(waitingReason))
+		Expect(message).To(ContainSubstring("1 of 4 ModelServices"))
+
+		// 50% of 4 dependents lets a second one roll out
+		admit, _, _, err = propagationDecision(&msvc, policy(intstr.FromString("50%")), dependents, newRevision, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeTrue())
+	})
+
+	It("should halt when a dependent failed to roll out", func() {
+		msvc := dependent("a", oldRevision)
+		dependents := []msv1alpha1.ModelService{msvc, dependent("b", newRevision, notReady, failed)}
+
+		admit, reason, message, err := propagationDecision(&msvc, policy(intstr.FromString("100%")), dependents, newRevision, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeFalse())
+		Expect(reason).To(Equal(haltedReason))
+		Expect(message).To(ContainSubstring("default/b"))
+	})
+
+	It("should compare each dependent with the revision of its own chain", func() {
+		const otherRevision = "00112233445566ff"
+		msvc := dependent("a", oldRevision)
+		// b inherits from the same parent through another base config
+		dependents := []msv1alpha1.ModelService{msvc, dependent("b", otherRevision, notReady)}
+		revisions := map[types.NamespacedName]string{{Namespace: "default", Name: "b"}: otherRevision}
+
+		admit, reason, _, err := propagationDecision(&msvc, policy(intstr.FromInt32(1)), dependents, newRevision, revisions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeFalse())
+		Expect(reason).To(Equal(waitingReason))
+
+		// a dependent that failed on a previous revision of its chain does not halt the rollout
+		dependents[1] = dependent("b", oldRevision, notReady, failed)
+		admit, _, _, err = propagationDecision(&msvc, policy(intstr.FromInt32(1)), dependents, newRevision, revisions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeTrue())
+	})
+
+	It("should hold a parent change back while a ModelService of a sibling base config rolls it out", func() {
+		ctx := context.Background()
+		parent := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "rolling-parent", Namespace: "default"},
+			Data: map[string]string{
+				"propagation":      "maxRolling: 1\n",
+				"decodeDeployment": "spec:\n  replicas: 2\n",
+			},
+		}
+		sibling := func(name string) *corev1.ConfigMap {
+			return &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Data:       map[string]string{"parent": "name: " + parent.Name + "\n"},
+			}
+		}
+		baseA, baseB := sibling("rolling-child-a"), sibling("rolling-child-b")
+		inheriting := func(name string, base *corev1.ConfigMap) *msv1alpha1.ModelService {
+			return &msv1alpha1.ModelService{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1},
+				Spec: msv1alpha1.ModelServiceSpec{
+					BaseConfigMapRef: &corev1.ObjectReference{Name: base.Name},
+					Routing:          msv1alpha1.Routing{ModelName: "model"},
+					ModelArtifacts:   msv1alpha1.ModelArtifacts{URI: "hf://org/model"},
+				},
+				Status: msv1alpha1.ModelServiceStatus{
+					ObservedGeneration: 1,
+					BaseConfigChain: []msv1alpha1.BaseConfigLayerReference{
+						{Kind: msv1alpha1.ConfigMapKind, Namespace: "default", Name: parent.Name},
+						{Kind: msv1alpha1.ConfigMapKind, Namespace: "default", Name: base.Name},
+					},
+				},
+			}
+		}
+		msvcA, msvcB := inheriting("rolling-a", baseA), inheriting("rolling-b", baseB)
+		reconciler := &ModelServiceReconciler{Scheme: k8sClient.Scheme()}
+
+		By("rolling out the changed parent to the ModelService of the other base config")
+		parent.Data["decodeDeployment"] = "spec:\n  replicas: 4\n"
+		reconciler.Client = newIndexedClient(parent, baseA, baseB)
+		chainB, _, err := reconciler.getBaseConfigChain(ctx, msvcB)
+		Expect(err).NotTo(HaveOccurred())
+		msvcB.Status.BaseConfigRevision = render.BaseConfigRevision(chainB)
+		msvcB.Status.Conditions = []metav1.Condition{notReady}
+
+		chainA, _, err := reconciler.getBaseConfigChain(ctx, msvcA)
+		Expect(err).NotTo(HaveOccurred())
+		msvcA.Status.BaseConfigRevision = oldRevision
+		reconciler.Client = newIndexedClient(parent, baseA, baseB, msvcA, msvcB)
+
+		By("holding the ModelService of the first base config back")
+		admit, condition, err := reconciler.admitBaseConfigRevision(ctx, msvcA, chainA, render.BaseConfigRevision(chainA))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeFalse())
+		Expect(condition.Reason).To(Equal(waitingReason))
+
+		By("admitting it once the other ModelService is ready")
+		msvcB.Status.Conditions = []metav1.Condition{ready}
+		reconciler.Client = newIndexedClient(parent, baseA, baseB, msvcA, msvcB)
+		admit, _, err = reconciler.admitBaseConfigRevision(ctx, msvcA, chainA, render.BaseConfigRevision(chainA))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admit).To(BeTrue())
+	})
+
+	It("should report a failed rollout from the deployment conditions", func() {
+		msvc := dependent("a", newRevision, notReady, metav1.Condition{
+			Type:    "DecodeProgressing",
+			Status:  metav1.ConditionFalse,
+			Reason:  progressDeadlineExceededReason,
+			Message: "ReplicaSet has timed out progressing",
+		})
+		Expect(newPropagatedCondition(&msvc, newRevision).Reason).To(Equal(failedReason))
+
+		msvc.Status.Conditions = []metav1.Condition{ready}
+		Expect(newPropagatedCondition(&msvc, newRevision).Status).To(Equal(metav1.ConditionTrue))
+	})
+})
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+)
+
+// anyNamespace matches every namespace in a BaseConfigGrant
+const anyNamespace = "*"
+
+// ReferencePolicy controls which namespaces other than its own a ModelService may use
+type ReferencePolicy struct {
+	// AllowedBaseConfigNamespaces lists the namespaces from which every
+	// ModelService may read base configs, e.g. a platform namespace.
+	// Other namespaces require a BaseConfigGrant
+	AllowedBaseConfigNamespaces []string
+}
+
+// ReferenceNotPermittedError is returned when a ModelService uses a namespace
+// other than its own without being allowed to
+type ReferenceNotPermittedError struct {
+	// Namespace of the ModelService
+	Namespace string
+	// Kind, Namespace and Name of the object referenced or created
+	Kind            string
+	TargetNamespace string
+	Name            string
+}
+
+func (e *ReferenceNotPermittedError) Error() string {
+	return fmt.Sprintf("%s %s/%s cannot be used from namespace %s: not in an allowed namespace and no BaseConfigGrant permits it",
+		e.Kind, e.TargetNamespace, e.Name, e.Namespace)
+}
+
+// checkBaseConfigReference returns a ReferenceNotPermittedError if a
+// ModelService in msvcNamespace may not read the base config referenced by ref.
+// namespace is used if ref does not set one
+func (r *ModelServiceReconciler) checkBaseConfigReference(ctx context.Context, msvcNamespace string, ref *corev1.ObjectReference, namespace string) error {
+	kind := ref.Kind
+	if kind == "" {
+		kind = msv1alpha1.ConfigMapKind
+	}
+	// cluster-scoped base configs are meant to be shared
+	if kind == msv1alpha1.ClusterModelServiceBaseConfigKind {
+		return nil
+	}
+
+	targetNamespace := ref.Namespace
+	if strings.TrimSpace(targetNamespace) == "" {
+		targetNamespace = namespace
+	}
+	if targetNamespace == msvcNamespace || slices.Contains(r.ReferencePolicy.AllowedBaseConfigNamespaces, targetNamespace) {
+		return nil
+	}
+
+	allowed, err := r.grants(ctx, msvcNamespace, targetNamespace, func(spec *msv1alpha1.BaseConfigGrantSpec) bool {
+		return slices.ContainsFunc(spec.To, func(to msv1alpha1.BaseConfigGrantTo) bool {
+			return to.Kind == kind && (to.Name == "" || to.Name == ref.Name)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &ReferenceNotPermittedError{Namespace: msvcNamespace, Kind: kind, TargetNamespace: targetNamespace, Name: ref.Name}
+	}
+	return nil
+}
+
+// checkChildConfigMaps returns a ReferenceNotPermittedError if one of configMaps
+// is outside the namespace of msvc and no BaseConfigGrant in its namespace
+// allows child ConfigMaps. The allowed base config namespaces do not apply
+func (r *ModelServiceReconciler) checkChildConfigMaps(ctx context.Context, msvc *msv1alpha1.ModelService, configMaps []corev1.ConfigMap) error {
+	for _, cm := range configMaps {
+		if cm.Namespace == "" || cm.Namespace == msvc.Namespace {
+			continue
+		}
+		allowed, err := r.grants(ctx, msvc.Namespace, cm.Namespace, func(spec *msv1alpha1.BaseConfigGrantSpec) bool {
+			return spec.AllowChildConfigMaps
+		})
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return &ReferenceNotPermittedError{Namespace: msvc.Namespace, Kind: msv1alpha1.ConfigMapKind, TargetNamespace: cm.Namespace, Name: cm.Name}
+		}
+	}
+	return nil
+}
+
+// grants returns true if a BaseConfigGrant in targetNamespace matches
+// msvcNamespace and permits the access checked by permits
+func (r *ModelServiceReconciler) grants(ctx context.Context, msvcNamespace, targetNamespace string, permits func(*msv1alpha1.BaseConfigGrantSpec) bool) (bool, error) {
+	var grants msv1alpha1.BaseConfigGrantList
+	if err := r.List(ctx, &grants, client.InNamespace(targetNamespace)); err != nil {
+		return false, fmt.Errorf("failed to list BaseConfigGrants: %w", err)
+	}
+	for i := range grants.Items {
+		spec := &grants.Items[i].Spec
+		fromMatches := slices.ContainsFunc(spec.From, func(from msv1alpha1.BaseConfigGrantFrom) bool {
+			return from.Namespace == anyNamespace || from.Namespace == msvcNamespace
+		})
+		if fromMatches && permits(spec) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// baseConfigGrantMapFunc maps a BaseConfigGrant to the ModelServices in its from namespaces
+// inheriting from a base config it lists, or to every ModelService in those namespaces
+// if it allows child ConfigMaps. Update events map both the old and the new grant,
+// so the ModelServices losing access are reconciled too
+func (r *ModelServiceReconciler) baseConfigGrantMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	grant, ok := obj.(*msv1alpha1.BaseConfigGrant)
+	if !ok {
+		return nil
+	}
+
+	var namespaces []string
+	for _, from := range grant.Spec.From {
+		namespace := from.Namespace
+		if namespace == anyNamespace {
+			namespaces = []string{metav1.NamespaceAll}
+			break
+		}
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	var requests []reconcile.Request
+	for _, namespace := range namespaces {
+		var modelServices msv1alpha1.ModelServiceList
+		if err := r.List(ctx, &modelServices, client.InNamespace(namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list ModelServices for BaseConfigGrant", "namespace", grant.Namespace, "name", grant.Name)
+			return nil
+		}
+		for i := range modelServices.Items {
+			msvc := &modelServices.Items[i]
+			if grant.Spec.AllowChildConfigMaps || grantListsBaseConfigOf(grant, msvc) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(msvc)})
+			}
+		}
+	}
+	return requests
+}
+
+// grantListsBaseConfigOf returns true if grant lists the base config referenced by msvc or one of its parents
+func grantListsBaseConfigOf(grant *msv1alpha1.BaseConfigGrant, msvc *msv1alpha1.ModelService) bool {
+	layers := msvc.Status.BaseConfigChain
+	if ref := msvc.Spec.BaseConfigMapRef; ref != nil {
+		layers = append(slices.Clone(layers), baseConfigLayerRef(ref, msvc.Namespace))
+	}
+	return slices.ContainsFunc(layers, func(layer msv1alpha1.BaseConfigLayerReference) bool {
+		return layer.Namespace == grant.Namespace && slices.ContainsFunc(grant.Spec.To, func(to msv1alpha1.BaseConfigGrantTo) bool {
+			return to.Kind == layer.Kind && (to.Name == "" || to.Name == layer.Name)
+		})
+	})
+}
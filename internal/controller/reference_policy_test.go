@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reference policy", func() {
+	const platformNamespace = "platform"
+
+	var (
+		ctx        context.Context
+		reconciler *ModelServiceReconciler
+		msvc       *msv1alpha1.ModelService
+		baseConfig *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &ModelServiceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: platformNamespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespace))).To(Succeed())
+
+		baseConfig = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-base-config", Namespace: platformNamespace},
+			Data:       map[string]string{"decodeDeployment": "spec:\n  replicas: 2\n"},
+		}
+		Expect(k8sClient.Create(ctx, baseConfig)).To(Succeed())
+
+		msvc = &msv1alpha1.ModelService{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default"},
+			Spec: msv1alpha1.ModelServiceSpec{
+				BaseConfigMapRef: &corev1.ObjectReference{Name: baseConfig.Name, Namespace: platformNamespace},
+				Routing:          msv1alpha1.Routing{ModelName: "model"},
+				ModelArtifacts:   msv1alpha1.ModelArtifacts{URI: "hf://org/model"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, baseConfig)).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &msv1alpha1.BaseConfigGrant{}, client.InNamespace(platformNamespace))).To(Succeed())
+	})
+
+	newGrant := func(spec msv1alpha1.BaseConfigGrantSpec) *msv1alpha1.BaseConfigGrant {
+		return &msv1alpha1.BaseConfigGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: platformNamespace},
+			Spec:       spec,
+		}
+	}
+
+	It("should refuse a base config in another namespace by default", func() {
+		_, _, err := reconciler.getBaseConfigChain(ctx, msvc)
+		var notPermittedErr *ReferenceNotPermittedError
+		Expect(err).To(BeAssignableToTypeOf(notPermittedErr))
+		Expect(newBaseConfigCondition(nil, err).Reason).To(Equal("ReferenceNotPermitted"))
+	})
+
+	It("should read a base config from an allowed namespace", func() {
+		reconciler.ReferencePolicy.AllowedBaseConfigNamespaces = []string{platformNamespace}
+		chain, _, err := reconciler.getBaseConfigChain(ctx, msvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(chain).To(HaveLen(1))
+	})
+
+	It("should read a base config granted to the namespace of the ModelService", func() {
+		grant := newGrant(msv1alpha1.BaseConfigGrantSpec{
+			From: []msv1alpha1.BaseConfigGrantFrom{{Namespace: msvc.Namespace}},
+			To:   []msv1alpha1.BaseConfigGrantTo{{Kind: msv1alpha1.ConfigMapKind, Name: baseConfig.Name}},
+		})
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+
+		Eventually(func() error {
+			_, _, err := reconciler.getBaseConfigChain(ctx, msvc)
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should refuse a base config granted to another namespace", func() {
+		grant := newGrant(msv1alpha1.BaseConfigGrantSpec{
+			From: []msv1alpha1.BaseConfigGrantFrom{{Namespace: "other"}},
+			To:   []msv1alpha1.BaseConfigGrantTo{{Kind: msv1alpha1.ConfigMapKind}},
+		})
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+
+		_, _, err := reconciler.getBaseConfigChain(ctx, msvc)
+		Expect(err).To(MatchError(ContainSubstring("no BaseConfigGrant permits it")))
+	})
+
+	It("should map a BaseConfigGrant to the ModelServices it concerns", func() {
+		parentRef := msv1alpha1.BaseConfigLayerReference{Kind: msv1alpha1.ConfigMapKind, Namespace: platformNamespace, Name: baseConfig.Name}
+		inheriting := msvc.DeepCopy()
+		inheriting.Name = "inherits-platform"
+		inheriting.Spec.BaseConfigMapRef = &corev1.ObjectReference{Name: "local-base-config"}
+		inheriting.Status.BaseConfigChain = []msv1alpha1.BaseConfigLayerReference{
+			parentRef,
+			{Kind: msv1alpha1.ConfigMapKind, Namespace: msvc.Namespace, Name: "local-base-config"},
+		}
+		unrelated := msvc.DeepCopy()
+		unrelated.Name = "unrelated"
+		unrelated.Spec.BaseConfigMapRef = nil
+		reconciler.Client = newIndexedClient(msvc, inheriting, unrelated)
+
+		By("mapping the ModelServices referencing a listed base config, directly or as a parent")
+		grant := newGrant(msv1alpha1.BaseConfigGrantSpec{
+			From: []msv1alpha1.BaseConfigGrantFrom{{Namespace: msvc.Namespace}},
+			To:   []msv1alpha1.BaseConfigGrantTo{{Kind: msv1alpha1.ConfigMapKind, Name: baseConfig.Name}},
+		})
+		Expect(reconciler.baseConfigGrantMapFunc(ctx, grant)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(msvc)},
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(inheriting)},
+		))
+
+		By("mapping every ModelService of the namespaces granted child ConfigMaps")
+		grant.Spec.To = nil
+		grant.Spec.AllowChildConfigMaps = true
+		Expect(reconciler.baseConfigGrantMapFunc(ctx, grant)).To(HaveLen(3))
+
+		By("ignoring the ModelServices of other namespaces")
+		grant.Spec.From = []msv1alpha1.BaseConfigGrantFrom{{Namespace: "other"}}
+		Expect(reconciler.baseConfigGrantMapFunc(ctx, grant)).To(BeEmpty())
+	})
+
+	It("should only create child ConfigMaps in another namespace if a grant allows it", func() {
+		configMaps := []corev1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: platformNamespace}}}
+
+		// allowed base config namespaces do not apply to child ConfigMaps
+		reconciler.ReferencePolicy.AllowedBaseConfigNamespaces = []string{platformNamespace}
+		Expect(reconciler.checkChildConfigMaps(ctx, msvc, configMaps)).ToNot(Succeed())
+
+		grant := newGrant(msv1alpha1.BaseConfigGrantSpec{
+			From:                 []msv1alpha1.BaseConfigGrantFrom{{Namespace: "*"}},
+			AllowChildConfigMaps: true,
+		})
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+		Eventually(func() error {
+			return reconciler.checkChildConfigMaps(ctx, msvc, configMaps)
+		}).Should(Succeed())
+	})
+})
@@ -0,0 +1,90 @@
+// Package tracing configures the OpenTelemetry tracer provider of the ModelService controller.
+// The span helpers shared with the render library are in pkg/tracing
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// ServiceName identifies the controller in exported traces
+const ServiceName = "llm-d-model-service"
+
+// Exporters supported by Setup
+const (
+	NoneExporter   = "none"
+	OTLPExporter   = "otlp"
+	StdoutExporter = "stdout"
+)
+
+// Options configures the exporter of the tracer provider
+type Options struct {
+	// Exporter is one of none, otlp or stdout; tracing is disabled if empty or none
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	// The OTEL_EXPORTER_OTLP_* environment variables are used if empty
+	Endpoint string
+	// Insecure disables TLS to the OTLP collector
+	Insecure bool
+	// SamplingRatio is the fraction of reconciles traced, between 0 and 1
+	SamplingRatio float64
+	// Writer receives the spans of the stdout exporter, os.Stdout if nil
+	Writer io.Writer
+}
+
+// Setup registers the global tracer provider described by opts.
+// The returned function flushes and stops the exporter
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	exporter, err := newExporter(ctx, opts)
+	if err != nil || exporter == nil {
+		return func(context.Context) error { return nil }, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// newExporter returns the span exporter selected by opts, or nil if tracing is disabled
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case "", NoneExporter:
+		return nil, nil
+
+	case OTLPExporter:
+		var clientOpts []otlptracegrpc.Option
+		if opts.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+		}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, clientOpts...)
+
+	case StdoutExporter:
+		writer := opts.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(writer), stdouttrace.WithPrettyPrint())
+
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q, expected one of %s, %s or %s", opts.Exporter, NoneExporter, OTLPExporter, StdoutExporter)
+	}
+}
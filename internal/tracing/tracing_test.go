@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		spans    bool
+		errorMsg string
+	}{
+		{
+			name: "disabled",
+			opts: Options{},
+		},
+		{
+			name:  "stdout",
+			opts:  Options{Exporter: StdoutExporter, SamplingRatio: 1},
+			spans: true,
+		},
+		{
+			name: "stdout without sampling",
+			opts: Options{Exporter: StdoutExporter},
+		},
+		{
+			name:     "unsupported exporter",
+			opts:     Options{Exporter: "zipkin"},
+			errorMsg: `unsupported tracing exporter "zipkin"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.opts.Writer = &out
+
+			shutdown, err := Setup(t.Context(), tt.opts)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			_, span := otel.Tracer("test").Start(t.Context(), "Reconcile")
+			span.End()
+			require.NoError(t, shutdown(t.Context()))
+
+			if tt.spans {
+				assert.Contains(t, out.String(), `"Name": "Reconcile"`)
+				assert.Contains(t, out.String(), ServiceName)
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
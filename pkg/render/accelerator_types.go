@@ -0,0 +1,218 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// ToNodeAffinity generates a NodeAffinity rule that requires nodes to match
+// the specified accelerator label key and one of the allowed values.
+// With an Ordered preference, every value is also preferred with a weight
+// decreasing in the order of the values.
+//
+// Returns an error if LabelKey is empty or LabelValues is empty.
+func AcceleratorTypesToNodeAffinity(a *v1alpha1.AcceleratorTypes) (*corev1.NodeAffinity, error) {
+	if a == nil {
+		return nil, nil
+	}
+	if a.LabelKey == "" {
+		return nil, fmt.Errorf("LabelKey must not be empty")
+	}
+	if len(a.LabelValues) == 0 {
+		return nil, fmt.Errorf("LabelValues must contain at least one value")
+	}
+
+	// Construct the node affinity rule
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      a.LabelKey,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   a.LabelValues,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if a.Preference == v1alpha1.AcceleratorPreferenceOrdered {
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = orderedAcceleratorTerms(a)
+	}
+
+	return nodeAffinity, nil
+}
+
+// orderedAcceleratorTerms returns a preferred term for every label value, from
+// a weight of 100 for the first one down to at least 1 for the last one
+func orderedAcceleratorTerms(a *v1alpha1.AcceleratorTypes) []corev1.PreferredSchedulingTerm {
+	count := len(a.LabelValues)
+	terms := make([]corev1.PreferredSchedulingTerm, 0, count)
+	for i, value := range a.LabelValues {
+		terms = append(terms, corev1.PreferredSchedulingTerm{
+			Weight: int32(max(1, 100*(count-i)/count)),
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{
+						Key:      a.LabelKey,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{value},
+					},
+				},
+			},
+		})
+	}
+	return terms
+}
+
+// AcceleratorLabel is set to the label value of an accelerator override on
+// the Deployment rendered for it, its selector and its pods. The selector of
+// the Deployment of the role requires it not to exist, so neither Deployment
+// selects the pods of the other
+const AcceleratorLabel = "llm-d.ai/accelerator"
+
+// AcceleratorDeploymentName returns the name of the Deployment of role
+// for the accelerator override of the given label value
+func AcceleratorDeploymentName(msvc *v1alpha1.ModelService, role string, labelValue string) string {
+	sanitizedName, err := sanitizeName(DeploymentName(msvc, role) + "-" + labelValue)
+	if err != nil {
+		return DeploymentName(msvc, role) + "-accelerator"
+	}
+	return sanitizedName
+}
+
+// withoutAcceleratorOverrides returns a copy of msvc whose roles only run on
+// the label values without an override. A role with an override for every
+// label value is scaled to zero. msvc is not modified
+func withoutAcceleratorOverrides(msvc *v1alpha1.ModelService) *v1alpha1.ModelService {
+	msvc = msvc.DeepCopy()
+	for _, pdSpec := range []*v1alpha1.PDSpec{msvc.Spec.Prefill, msvc.Spec.Decode} {
+		if pdSpec == nil || pdSpec.AcceleratorTypes == nil || len(pdSpec.AcceleratorTypes.Overrides) == 0 {
+			continue
+		}
+		a := pdSpec.AcceleratorTypes
+		remaining := slices.DeleteFunc(slices.Clone(a.LabelValues), func(value string) bool {
+			return slices.ContainsFunc(a.Overrides, func(o v1alpha1.AcceleratorOverride) bool { return o.LabelValue == value })
+		})
+		if len(remaining) == 0 {
+			pdSpec.Replicas = ptr.To(int32(0))
+		} else {
+			a.LabelValues = remaining
+		}
+		a.Overrides = nil
+	}
+	return msvc
+}
+
+// acceleratorVariant returns a copy of msvc whose role runs on the label value
+// of override only, with the replicas, parallelism and containers of override.
+// msvc is not modified
+func acceleratorVariant(msvc *v1alpha1.ModelService, role string, override v1alpha1.AcceleratorOverride) (*v1alpha1.ModelService, error) {
+	variant := withoutAcceleratorOverrides(msvc)
+	pdSpec, err := templatePDSpec(variant, role)
+	if err != nil {
+		return nil, err
+	}
+
+	pdSpec.AcceleratorTypes = &v1alpha1.AcceleratorTypes{
+		LabelKey:    pdSpec.AcceleratorTypes.LabelKey,
+		LabelValues: []string{override.LabelValue},
+	}
+	// the API server defaults replicas to 1, whatever the base config says
+	pdSpec.Replicas = ptr.To(int32(1))
+	if override.Replicas != nil {
+		pdSpec.Replicas = ptr.To(*override.Replicas)
+	}
+	if override.Parallelism != nil {
+		pdSpec.Parallelism = override.Parallelism.DeepCopy()
+	}
+
+	for _, containerOverride := range override.Containers {
+		i := slices.IndexFunc(pdSpec.Containers, func(c v1alpha1.ContainerSpec) bool { return c.Name == containerOverride.Name })
+		if i < 0 {
+			// the container comes from the base config, and is merged with it by name
+			pdSpec.Containers = append(pdSpec.Containers, v1alpha1.ContainerSpec{Name: containerOverride.Name})
+			i = len(pdSpec.Containers) - 1
+		}
+		if containerOverride.Args != nil {
+			pdSpec.Containers[i].Args = slices.Clone(containerOverride.Args)
+		}
+		if containerOverride.Resources != nil {
+			pdSpec.Containers[i].Resources = *containerOverride.Resources.DeepCopy()
+		}
+	}
+
+	return variant, nil
+}
+
+// renderAcceleratorDeployments returns a Deployment of role for every accelerator
+// override of msvc. Each one is rendered from the base config layers like the
+// Deployment of the role, from the acceleratorVariant of msvc, so that the
+// templates of the base config see the parallelism of the override
+func renderAcceleratorDeployments(ctx context.Context, msvc *v1alpha1.ModelService, role string, baseConfigMap *corev1.ConfigMap, layers []BaseConfigLayer, opts Options) ([]*appsv1.Deployment, error) {
+	pdSpec, err := templatePDSpec(msvc, role)
+	if err != nil || pdSpec == nil || pdSpec.AcceleratorTypes == nil {
+		return nil, err
+	}
+
+	var deployments []*appsv1.Deployment
+	for _, override := range pdSpec.AcceleratorTypes.Overrides {
+		name := AcceleratorDeploymentName(msvc, role, override.LabelValue)
+		if !slices.Contains(pdSpec.AcceleratorTypes.LabelValues, override.LabelValue) {
+			return nil, &MergeError{Kind: "Deployment", Name: name, Err: fmt.Errorf("override label value %q is not one of the label values", override.LabelValue)}
+		}
+
+		variant, err := acceleratorVariant(msvc, role, override)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := renderLayers(ctx, variant, baseConfigMap, layers, opts)
+		if err != nil {
+			return nil, err
+		}
+		deployment := rendered.DecodeDeployment
+		if role == PREFILL_ROLE {
+			deployment = rendered.PrefillDeployment
+		}
+
+		deployment.Name = name
+		deployment.Labels = withAcceleratorLabel(deployment.Labels, override.LabelValue)
+		deployment.Spec.Selector = withAcceleratorSelector(deployment.Spec.Selector, override.LabelValue)
+		deployment.Spec.Template.Labels = withAcceleratorLabel(deployment.Spec.Template.Labels, override.LabelValue)
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
+// withAcceleratorSelector returns a copy of selector matching AcceleratorLabel
+// set to labelValue, instead of its absence
+func withAcceleratorSelector(selector *metav1.LabelSelector, labelValue string) *metav1.LabelSelector {
+	selector = selector.DeepCopy()
+	selector.MatchLabels = withAcceleratorLabel(selector.MatchLabels, labelValue)
+	selector.MatchExpressions = slices.DeleteFunc(selector.MatchExpressions, func(r metav1.LabelSelectorRequirement) bool {
+		return r.Key == AcceleratorLabel
+	})
+	return selector
+}
+
+// withAcceleratorLabel returns a copy of labels with AcceleratorLabel set to labelValue.
+// The labels of a rendered deployment, its selector and its pods may share a map
+func withAcceleratorLabel(labels map[string]string, labelValue string) map[string]string {
+	labels = maps.Clone(labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[AcceleratorLabel] = labelValue
+	return labels
+}
@@ -0,0 +1,242 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestToNodeAffinity(t *testing.T) {
+	tests := []struct {
+		name        string
+		accelerator v1alpha1.AcceleratorTypes
+		expectError bool
+	}{
+		// valid label key and values
+		{
+			name: "valid accelerator",
+			accelerator: v1alpha1.AcceleratorTypes{
+				LabelKey:    "nvidia.com/gpu.product",
+				LabelValues: []string{"A100", "H100"},
+			},
+			expectError: false,
+		},
+		// missing LabelKey
+		{
+			name: "missing label key",
+			accelerator: v1alpha1.AcceleratorTypes{
+				LabelKey:    "",
+				LabelValues: []string{"A100"},
+			},
+			expectError: true,
+		},
+		// empty LabelValues slice
+		{
+			name: "empty label values",
+			accelerator: v1alpha1.AcceleratorTypes{
+				LabelKey:    "nvidia.com/gpu.product",
+				LabelValues: []string{},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeAffinity, err := AcceleratorTypesToNodeAffinity(&tt.accelerator)
+
+			if tt.expectError {
+				assert.Error(t, err, "expected error but got none")
+				assert.Nil(t, nodeAffinity)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, nodeAffinity)
+				assert.NotNil(t, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+
+				// Validate NodeSelectorTerm with correct MatchExpression
+				terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				assert.Len(t, terms, 1)
+				assert.Len(t, terms[0].MatchExpressions, 1)
+
+				expr := terms[0].MatchExpressions[0]
+				assert.Equal(t, corev1.NodeSelectorOpIn, expr.Operator)
+				assert.Equal(t, tt.accelerator.LabelKey, expr.Key)
+				assert.ElementsMatch(t, tt.accelerator.LabelValues, expr.Values)
+			}
+		})
+	}
+}
+
+func TestOrderedAcceleratorPreference(t *testing.T) {
+	nodeAffinity, err := AcceleratorTypesToNodeAffinity(&v1alpha1.AcceleratorTypes{
+		LabelKey:    "nvidia.com/gpu.product",
+		LabelValues: []string{"H100", "A100", "L40S"},
+		Preference:  v1alpha1.AcceleratorPreferenceOrdered,
+	})
+	assert.NoError(t, err)
+
+	// every value stays allowed, and the first one is preferred
+	assert.Equal(t, []string{"H100", "A100", "L40S"},
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+
+	terms := nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, terms, 3)
+	for i, expected := range []struct {
+		value  string
+		weight int32
+	}{{"H100", 100}, {"A100", 66}, {"L40S", 33}} {
+		assert.Equal(t, expected.weight, terms[i].Weight)
+		assert.Equal(t, []string{expected.value}, terms[i].Preference.MatchExpressions[0].Values)
+	}
+
+	// values are treated equally by default
+	nodeAffinity, err = AcceleratorTypesToNodeAffinity(&v1alpha1.AcceleratorTypes{
+		LabelKey:    "nvidia.com/gpu.product",
+		LabelValues: []string{"H100", "A100"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+}
+
+func TestAcceleratorOverrides(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	// the base config sizes vllm with the tensor parallelism of the pods
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: msvcNamespace},
+		Data: map[string]string{
+			"decodeDeployment": `spec:
+  template:
+    spec:
+      containers:
+      - name: vllm
+        args:
+        - "--tensor-parallel-size={{ getTensorParallelism "decode" }}"
+`,
+		},
+	}
+	twoGPUs := corev1.ResourceRequirements{Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")}}
+	a100 := v1alpha1.AcceleratorOverride{
+		LabelValue:  "A100",
+		Replicas:    ptr.To(int32(3)),
+		Parallelism: &v1alpha1.Parallelism{Tensor: ptr.To(int32(2))},
+		Containers: []v1alpha1.AcceleratorContainerOverride{{
+			Name:      "vllm",
+			Args:      []string{"--max-model-len=8192"},
+			Resources: &twoGPUs,
+		}},
+	}
+	requiredValues := func(spec corev1.PodSpec) []string {
+		return spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values
+	}
+
+	tests := []struct {
+		name      string
+		overrides []v1alpha1.AcceleratorOverride
+		// expected replicas and required label values of the decode deployment
+		replicas int32
+		values   []string
+		// expected deployments of the accelerator overrides
+		accelerators int
+		expectError  bool
+	}{
+		{
+			name:     "no override",
+			replicas: 2,
+			values:   []string{"H100", "A100"},
+		},
+		{
+			name:         "the decode deployment runs on the label values without an override",
+			overrides:    []v1alpha1.AcceleratorOverride{a100},
+			replicas:     2,
+			values:       []string{"H100"},
+			accelerators: 1,
+		},
+		{
+			name:         "the decode deployment is scaled to zero if every label value has an override",
+			overrides:    []v1alpha1.AcceleratorOverride{a100, {LabelValue: "H100"}},
+			replicas:     0,
+			values:       []string{"H100", "A100"},
+			accelerators: 2,
+		},
+		{
+			name:        "an override of another label value is rejected",
+			overrides:   []v1alpha1.AcceleratorOverride{{LabelValue: "L40S"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := createMSVCWithDecode(&v1alpha1.PDSpec{
+				ModelServicePodSpec: v1alpha1.ModelServicePodSpec{
+					Replicas:   ptr.To(int32(2)),
+					Containers: []v1alpha1.ContainerSpec{{Name: "vllm", Args: []string{"--max-model-len=32768"}}},
+				},
+				AcceleratorTypes: &v1alpha1.AcceleratorTypes{
+					LabelKey:    "nvidia.com/gpu.product",
+					LabelValues: []string{"H100", "A100"},
+					Overrides:   tt.overrides,
+				},
+			})
+			original := msvc.DeepCopy()
+
+			cr, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+			if tt.expectError {
+				var mergeErr *MergeError
+				assert.True(t, errors.As(err, &mergeErr), "expected a MergeError, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, original, msvc, "msvc must not be modified")
+
+			decode := cr.DecodeDeployment
+			assert.Equal(t, tt.replicas, *decode.Spec.Replicas)
+			assert.Equal(t, tt.values, requiredValues(decode.Spec.Template.Spec))
+			assert.Equal(t, []string{"--max-model-len=32768", "--tensor-parallel-size=1"}, decode.Spec.Template.Spec.Containers[0].Args)
+			assert.NotContains(t, decode.Spec.Template.Labels, AcceleratorLabel)
+			require.Len(t, cr.DecodeAcceleratorDeployments, tt.accelerators)
+			if tt.accelerators == 0 {
+				return
+			}
+
+			deployment := cr.DecodeAcceleratorDeployments[0]
+			assert.Equal(t, AcceleratorDeploymentName(msvc, DECODE_ROLE, "A100"), deployment.Name)
+			assert.Equal(t, "msvc-test-decode-a100", deployment.Name)
+			assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+			assert.Equal(t, []string{"A100"}, requiredValues(deployment.Spec.Template.Spec))
+			for _, labels := range []map[string]string{deployment.Labels, deployment.Spec.Selector.MatchLabels, deployment.Spec.Template.Labels} {
+				assert.Equal(t, "A100", labels[AcceleratorLabel])
+			}
+			assert.NotContains(t, decode.Spec.Selector.MatchLabels, AcceleratorLabel)
+
+			// neither deployment selects the pods of the other
+			selector, err := metav1.LabelSelectorAsSelector(decode.Spec.Selector)
+			require.NoError(t, err)
+			acceleratorSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+			require.NoError(t, err)
+			assert.True(t, selector.Matches(labels.Set(decode.Spec.Template.Labels)))
+			assert.False(t, selector.Matches(labels.Set(deployment.Spec.Template.Labels)))
+			assert.True(t, acceleratorSelector.Matches(labels.Set(deployment.Spec.Template.Labels)))
+			assert.False(t, acceleratorSelector.Matches(labels.Set(decode.Spec.Template.Labels)))
+
+			container := deployment.Spec.Template.Spec.Containers[0]
+			assert.Equal(t, []string{"--max-model-len=8192", "--tensor-parallel-size=2"}, container.Args)
+			assert.Equal(t, twoGPUs, container.Resources)
+			assert.Len(t, deployment.OwnerReferences, 1)
+		})
+	}
+}
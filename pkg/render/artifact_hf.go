@@ -0,0 +1,176 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	mustRegisterArtifactSource(hfSource{})
+}
+
+// hfSource serves models from Hugging Face, hf://<repo-id>/<model-id>.
+// The model server downloads the model into the model volume, unless
+// it is downloaded beforehand by an init container or the model cache Job
+type hfSource struct{}
+
+func (hfSource) Scheme() string {
+	return MODEL_ARTIFACT_URI_HF
+}
+
+func (hfSource) Parse(artifacts *msv1alpha1.ModelArtifacts) error {
+	if _, _, err := parseHFURI(artifacts); err != nil {
+		return err
+	}
+	return validateHFToken(artifacts)
+}
+
+// MountedModelPath is the storage root used as HF_HOME, or the download directory,
+// a link to the snapshot of the model in the Hugging Face cache, if the model
+// is downloaded beforehand
+func (s hfSource) MountedModelPath(msvc *msv1alpha1.ModelService) (string, error) {
+	if shouldDownloadModel(msvc) {
+		return s.DownloadDir(msvc)
+	}
+	return ModelStorageRoot, nil
+}
+
+// Volumes adds the token file volume if the model server or the download
+// init container reads the token from a file
+func (s hfSource) Volumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	volumes := downloadedModelVolumes(s, msvc)
+	downloadInPod := shouldDownloadModel(msvc) && msvc.Spec.ModelArtifacts.Cache == nil
+	if hfTokenInModelServer(msvc) || downloadInPod {
+		volumes = append(volumes, hfTokenVolumes(msvc)...)
+	}
+	return volumes
+}
+
+// VolumeMounts is writable, as the model server downloads the model
+func (hfSource) VolumeMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	mounts := modelStorageMount(false)
+	if hfTokenInModelServer(msvc) {
+		mounts = append(mounts, hfTokenMounts(msvc)...)
+	}
+	return mounts
+}
+
+// Env sets the token, unless it is only injected into the download, HF_HOME to
+// the storage root, and HF_HUB_OFFLINE if the model is already downloaded.
+// The model is downloaded into the cache of HF_HOME, so the model server
+// finds it offline by its <repo-id>/<model-id> as well as by its path
+func (hfSource) Env(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	envs := []corev1.EnvVar{}
+	if hfTokenInModelServer(msvc) {
+		envs = append(envs, hfTokenEnvs(msvc)...)
+	}
+
+	envs = append(envs, corev1.EnvVar{
+		Name:  ENV_HF_HOME,
+		Value: ModelStorageRoot,
+	})
+
+	if shouldDownloadModel(msvc) {
+		envs = append(envs, corev1.EnvVar{Name: ENV_HF_HUB_OFFLINE, Value: "1"})
+	}
+	return envs
+}
+
+// TemplateVars sets HFModelName and ModelPath to <repo-id>/<model-id>, and ModelRevision
+func (hfSource) TemplateVars(msvc *msv1alpha1.ModelService, vars *TemplateVars) error {
+	vars.HFModelName = strings.TrimPrefix(msvc.Spec.ModelArtifacts.URI, MODEL_ARTIFACT_URI_HF_PREFIX)
+	vars.ModelPath = vars.HFModelName
+	vars.ModelRevision = modelRevision(msvc)
+	return nil
+}
+
+func (hfSource) AlwaysDownload() bool {
+	return false
+}
+
+// DownloadDir is /model-cache/<repo-id>/<model-id>/<revision>, a link
+// to the snapshot of the model in the Hugging Face cache
+func (hfSource) DownloadDir(msvc *msv1alpha1.ModelService) (string, error) {
+	repoID, modelID, err := parseHFURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return "", err
+	}
+	// branches such as refs/pr/1 must stay a single path segment
+	revision := strings.ReplaceAll(modelRevision(msvc), pathSep, "--")
+	return strings.Join([]string{ModelStorageRoot, repoID, modelID, revision}, pathSep), nil
+}
+
+// DownloadCommand runs huggingface-cli, which the model server image usually provides.
+// The model is downloaded into the Hugging Face cache of HF_HOME, which locks the
+// files being downloaded, and dir is set to the snapshot path printed by huggingface-cli
+func (hfSource) DownloadCommand(msvc *msv1alpha1.ModelService, download *msv1alpha1.ModelDownload) []string {
+	repoID, modelID, _ := parseHFURI(&msvc.Spec.ModelArtifacts)
+	args := []string{
+		`rmdir "$dir" && dir="$(huggingface-cli`, "download", shellQuote(repoID + pathSep + modelID),
+		"--revision", shellQuote(modelRevision(msvc)),
+		"--cache-dir", shellQuote(hfHubCacheDir),
+	}
+	if len(download.AllowPatterns) > 0 {
+		args = append(args, "--include")
+		for _, pattern := range download.AllowPatterns {
+			args = append(args, shellQuote(pattern))
+		}
+	}
+	if len(download.IgnorePatterns) > 0 {
+		args = append(args, "--exclude")
+		for _, pattern := range download.IgnorePatterns {
+			args = append(args, shellQuote(pattern))
+		}
+	}
+	args[len(args)-1] += `)"`
+	return args
+}
+
+func (hfSource) DownloadEnv(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	return hfTokenEnvs(msvc)
+}
+
+func (hfSource) DefaultDownloadImage() string {
+	return ""
+}
+
+// isHFURI returns True if the URI begins with hf://
+func isHFURI(uri string) bool {
+	return strings.HasPrefix(uri, MODEL_ARTIFACT_URI_HF_PREFIX)
+}
+
+// parseHFURI returns parts from a valid hf URI, or
+// returns an error if the HF URI is invalid
+// returns two strings:
+// First string is the repo-id
+// Second string is the model-id
+func parseHFURI(modelArtifact *msv1alpha1.ModelArtifacts) (string, string, error) {
+	var repoID string
+	var modelID string
+	if modelArtifact == nil {
+		return repoID, modelID, fmt.Errorf("modelArtifact is nil")
+	}
+
+	uri := modelArtifact.URI
+	if !isHFURI(uri) {
+		return repoID, modelID, fmt.Errorf("URI does not have hf prefix: %s", uri)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(uri, MODEL_ARTIFACT_URI_HF_PREFIX), pathSep)
+	if len(parts) != 2 {
+		return repoID, modelID, fmt.Errorf("invalid hf URI format: %s; need hf://<repo-id>/<model-id>", uri)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// modelRevision returns the revision of the model, main by default
+func modelRevision(msvc *msv1alpha1.ModelService) string {
+	if msvc.Spec.ModelArtifacts.Revision != "" {
+		return msvc.Spec.ModelArtifacts.Revision
+	}
+	return DEFAULT_HF_REVISION
+}
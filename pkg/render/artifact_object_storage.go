@@ -0,0 +1,158 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	mustRegisterArtifactSource(objectStorageSource{scheme: MODEL_ARTIFACT_URI_S3})
+	mustRegisterArtifactSource(objectStorageSource{scheme: MODEL_ARTIFACT_URI_GCS})
+}
+
+// objectStorageSource serves models stored in S3 compatible or Cloud Storage
+// buckets, s3://<bucket>/<prefix> or gs://<bucket>/<prefix>. The model is
+// always downloaded with the AWS CLI, as the model servers cannot read buckets
+type objectStorageSource struct {
+	scheme string
+}
+
+func (s objectStorageSource) Scheme() string {
+	return s.scheme
+}
+
+func (objectStorageSource) Parse(artifacts *msv1alpha1.ModelArtifacts) error {
+	_, _, err := parseObjectStorageURI(artifacts)
+	return err
+}
+
+func (s objectStorageSource) MountedModelPath(msvc *msv1alpha1.ModelService) (string, error) {
+	return s.DownloadDir(msvc)
+}
+
+func (s objectStorageSource) Volumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	return downloadedModelVolumes(s, msvc)
+}
+
+func (objectStorageSource) VolumeMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	return modelStorageMount(false)
+}
+
+// Env is empty, the model servers get no credentials
+func (objectStorageSource) Env(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	return []corev1.EnvVar{}
+}
+
+// TemplateVars sets ModelPath to <prefix>
+func (objectStorageSource) TemplateVars(msvc *msv1alpha1.ModelService, vars *TemplateVars) error {
+	_, prefix, err := parseObjectStorageURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return err
+	}
+	vars.ModelPath = prefix
+	return nil
+}
+
+func (objectStorageSource) AlwaysDownload() bool {
+	return true
+}
+
+// DownloadDir is /model-cache/<prefix>
+func (objectStorageSource) DownloadDir(msvc *msv1alpha1.ModelService) (string, error) {
+	_, prefix, err := parseObjectStorageURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return "", err
+	}
+	return ModelStorageRoot + pathSep + prefix, nil
+}
+
+// DownloadCommand runs aws s3 sync. gs:// buckets are read through the
+// S3 compatible XML API of Cloud Storage
+func (objectStorageSource) DownloadCommand(msvc *msv1alpha1.ModelService, download *msv1alpha1.ModelDownload) []string {
+	bucket, prefix, _ := parseObjectStorageURI(&msvc.Spec.ModelArtifacts)
+	args := []string{
+		"aws", "s3", "sync", shellQuote(MODEL_ARTIFACT_URI_S3_PREFIX + bucket + pathSep + prefix), `"$dir"`,
+		"--only-show-errors",
+	}
+	// filters apply in order, so only the allowed files are included
+	// before the ignored ones are excluded again
+	if len(download.AllowPatterns) > 0 {
+		args = append(args, "--exclude", shellQuote("*"))
+		for _, pattern := range download.AllowPatterns {
+			args = append(args, "--include", shellQuote(pattern))
+		}
+	}
+	for _, pattern := range download.IgnorePatterns {
+		args = append(args, "--exclude", shellQuote(pattern))
+	}
+	return args
+}
+
+// DownloadEnv returns the standard AWS env vars read from the authentication
+// secret, and the endpoint of the object storage. gs:// URIs default to the
+// Cloud Storage endpoint, which accepts HMAC keys as AWS credentials
+func (s objectStorageSource) DownloadEnv(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	artifacts := msvc.Spec.ModelArtifacts
+
+	var envs []corev1.EnvVar
+	if artifacts.AuthSecretName != nil {
+		envs = append(envs,
+			secretKeyEnv(ENV_AWS_ACCESS_KEY_ID, *artifacts.AuthSecretName, false),
+			secretKeyEnv(ENV_AWS_SECRET_ACCESS_KEY, *artifacts.AuthSecretName, false),
+			secretKeyEnv(ENV_AWS_DEFAULT_REGION, *artifacts.AuthSecretName, true),
+		)
+	}
+
+	endpoint := artifacts.Endpoint
+	if endpoint == "" && s.scheme == MODEL_ARTIFACT_URI_GCS {
+		endpoint = GCS_ENDPOINT_URL
+	}
+	if endpoint != "" {
+		envs = append(envs, corev1.EnvVar{Name: ENV_AWS_ENDPOINT_URL, Value: endpoint})
+	}
+	return envs
+}
+
+func (objectStorageSource) DefaultDownloadImage() string {
+	return DEFAULT_OBJECT_STORAGE_DOWNLOAD_IMAGE
+}
+
+// isS3URI returns True if the URI begins with s3://
+func isS3URI(uri string) bool {
+	return strings.HasPrefix(uri, MODEL_ARTIFACT_URI_S3_PREFIX)
+}
+
+// isGCSURI returns True if the URI begins with gs://
+func isGCSURI(uri string) bool {
+	return strings.HasPrefix(uri, MODEL_ARTIFACT_URI_GCS_PREFIX)
+}
+
+// isObjectStorageURI returns True if the URI is a s3:// or gs:// URI
+func isObjectStorageURI(uri string) bool {
+	return isS3URI(uri) || isGCSURI(uri)
+}
+
+// parseObjectStorageURI returns the bucket and the prefix of a valid s3 or gs URI,
+// or returns an error if the URI is invalid
+func parseObjectStorageURI(modelArtifact *msv1alpha1.ModelArtifacts) (string, string, error) {
+	if modelArtifact == nil {
+		return "", "", fmt.Errorf("modelArtifact is nil")
+	}
+
+	uri := modelArtifact.URI
+	scheme, tail, ok := strings.Cut(uri, "://")
+	if !ok || !isObjectStorageURI(uri) {
+		return "", "", fmt.Errorf("URI does not have s3 or gs prefix: %s", uri)
+	}
+
+	bucket, prefix, _ := strings.Cut(tail, pathSep)
+	prefix = strings.Trim(prefix, pathSep)
+	if bucket == "" || prefix == "" {
+		return "", "", fmt.Errorf("invalid %s URI format: %s; need %s://<bucket>/<prefix>", scheme, uri, scheme)
+	}
+
+	return bucket, prefix, nil
+}
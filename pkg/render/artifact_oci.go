@@ -0,0 +1,99 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	mustRegisterArtifactSource(ociSource{})
+}
+
+// ociSource serves models packaged as images, oci://<image-repo>/<image-name>:<tag>,
+// mounted read-only as an image volume. The model may be in a directory of the
+// image, oci://<image-repo>/<image-name>:<tag>::<path/to/model>. Image volumes
+// require the ImageVolume feature gate of Kubernetes 1.31 or later
+type ociSource struct{}
+
+func (ociSource) Scheme() string {
+	return MODEL_ARTIFACT_URI_OCI
+}
+
+func (ociSource) Parse(artifacts *msv1alpha1.ModelArtifacts) error {
+	_, _, err := parseOCIURI(artifacts)
+	return err
+}
+
+// MountedModelPath is /model-cache/<path/to/model>, or the storage root
+// where the image is mounted
+func (ociSource) MountedModelPath(msvc *msv1alpha1.ModelService) (string, error) {
+	_, modelPath, err := parseOCIURI(&msvc.Spec.ModelArtifacts)
+	if err != nil || modelPath == "" {
+		return ModelStorageRoot, nil
+	}
+	return ModelStorageRoot + pathSep + modelPath, nil
+}
+
+// Volumes pulls the image by the digest of modelArtifacts.verification, if set
+func (ociSource) Volumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	reference, _, err := parseOCIURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: ModelStorageVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Image: &corev1.ImageVolumeSource{
+				Reference:  pinnedImageReference(msvc, reference),
+				PullPolicy: corev1.PullIfNotPresent,
+			},
+		},
+	}}
+}
+
+// VolumeMounts is read-only, as image volumes always are
+func (ociSource) VolumeMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	return modelStorageMount(true)
+}
+
+func (ociSource) Env(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	return []corev1.EnvVar{}
+}
+
+// TemplateVars sets ModelPath to <path/to/model>, empty if the model is at the root of the image
+func (ociSource) TemplateVars(msvc *msv1alpha1.ModelService, vars *TemplateVars) error {
+	_, modelPath, err := parseOCIURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return err
+	}
+	vars.ModelPath = modelPath
+	return nil
+}
+
+// isOCIURI returns True if the URI begins with oci://
+func isOCIURI(uri string) bool {
+	return strings.HasPrefix(uri, MODEL_ARTIFACT_URI_OCI_PREFIX)
+}
+
+// parseOCIURI returns the image reference and the path of the model in the image
+// of a valid oci URI, or returns an error if the OCI URI is invalid
+func parseOCIURI(modelArtifact *msv1alpha1.ModelArtifacts) (string, string, error) {
+	if modelArtifact == nil {
+		return "", "", fmt.Errorf("modelArtifact is nil")
+	}
+
+	uri := modelArtifact.URI
+	if !isOCIURI(uri) {
+		return "", "", fmt.Errorf("URI does not have oci prefix: %s", uri)
+	}
+
+	reference, modelPath, _ := strings.Cut(strings.TrimPrefix(uri, MODEL_ARTIFACT_URI_OCI_PREFIX), "::")
+	if reference == "" || strings.ContainsAny(reference, " \t\n") {
+		return "", "", fmt.Errorf("invalid oci URI format: %s; need oci://<image-repo>/<image-name>:<tag>[::<path/to/model>]", uri)
+	}
+
+	return reference, strings.Trim(modelPath, pathSep), nil
+}
@@ -0,0 +1,128 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	mustRegisterArtifactSource(pvcSource{})
+}
+
+// pvcSource serves models stored on an existing PersistentVolumeClaim,
+// pvc://<pvc-name>/<path/to/model>, mounted read-only
+type pvcSource struct{}
+
+func (pvcSource) Scheme() string {
+	return MODEL_ARTIFACT_URI_PVC
+}
+
+func (pvcSource) Parse(artifacts *msv1alpha1.ModelArtifacts) error {
+	_, err := parsePVCURI(artifacts)
+	return err
+}
+
+// MountedModelPath is /model-cache/<path/to/model>, where VolumeMounts mounts the model
+func (pvcSource) MountedModelPath(msvc *msv1alpha1.ModelService) (string, error) {
+	subPath, err := pvcModelSubPath(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return "", err
+	}
+	if subPath == "" {
+		return ModelStorageRoot, nil
+	}
+	return ModelStorageRoot + pathSep + subPath, nil
+}
+
+func (pvcSource) Volumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	parts, err := parsePVCURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: ModelStorageVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: parts[0],
+				ReadOnly:  true,
+			},
+		},
+	}}
+}
+
+// VolumeMounts only mounts the directory of the model, at the mounted model path,
+// so that the other models of a shared claim are not exposed
+func (pvcSource) VolumeMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	subPath, err := pvcModelSubPath(&msvc.Spec.ModelArtifacts)
+	if err != nil || subPath == "" {
+		return modelStorageMount(true)
+	}
+	return []corev1.VolumeMount{{
+		Name:      ModelStorageVolumeName,
+		MountPath: ModelStorageRoot + pathSep + subPath,
+		SubPath:   subPath,
+		ReadOnly:  true,
+	}}
+}
+
+func (pvcSource) Env(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	return []corev1.EnvVar{}
+}
+
+// TemplateVars sets ModelPath to <path/to/model>
+func (pvcSource) TemplateVars(msvc *msv1alpha1.ModelService, vars *TemplateVars) error {
+	parts, err := parsePVCURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return err
+	}
+	vars.ModelPath = strings.Join(parts[1:], pathSep)
+	return nil
+}
+
+// ModelClaimName returns the name of the PersistentVolumeClaim holding the model
+// of a pvc:// URI, and false for other URIs
+func ModelClaimName(msvc *msv1alpha1.ModelService) (string, bool) {
+	parts, err := parsePVCURI(&msvc.Spec.ModelArtifacts)
+	if err != nil || parts[0] == "" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// pvcModelSubPath returns <path/to/model> of a pvc URI, without leading or
+// trailing separators, the subPath of the claim mounted in the containers
+func pvcModelSubPath(modelArtifact *msv1alpha1.ModelArtifacts) (string, error) {
+	parts, err := parsePVCURI(modelArtifact)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(strings.Join(parts[1:], pathSep), pathSep), nil
+}
+
+// isPVCURI returns True if the URI begins with pvc://
+func isPVCURI(uri string) bool {
+	return strings.HasPrefix(uri, MODEL_ARTIFACT_URI_PVC_PREFIX)
+}
+
+// parsePVCURI returns parts from a valid pvc URI, or
+// returns an error if the PVC URI is invalid
+func parsePVCURI(modelArtifact *msv1alpha1.ModelArtifacts) ([]string, error) {
+	if modelArtifact == nil {
+		return nil, fmt.Errorf("modelArtifact is nil")
+	}
+
+	uri := modelArtifact.URI
+	if !isPVCURI(uri) {
+		return nil, fmt.Errorf("URI does not have pvc prefix: %s", uri)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(uri, MODEL_ARTIFACT_URI_PVC_PREFIX), pathSep)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid pvc URI format: %s; need pvc://<pvc-name>/model/path", uri)
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,159 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ArtifactSource handles the model artifacts of one modelArtifacts.uri scheme.
+// It is the only place knowing how the model of its scheme reaches the
+// containers mounting the model volume
+type ArtifactSource interface {
+	// Scheme returns the URI scheme handled by the source, without ://
+	Scheme() string
+
+	// Parse returns an error if artifacts.URI is not a valid URI of the scheme
+	Parse(artifacts *msv1alpha1.ModelArtifacts) error
+
+	// MountedModelPath returns the path of the model in the containers mounting the model volume
+	MountedModelPath(msvc *msv1alpha1.ModelService) (string, error)
+
+	// Volumes returns the volumes of the prefill and decode pods
+	Volumes(msvc *msv1alpha1.ModelService) []corev1.Volume
+
+	// VolumeMounts returns the volume mounts of the containers mounting the model volume
+	VolumeMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount
+
+	// Env returns the env vars of the containers mounting the model volume
+	Env(msvc *msv1alpha1.ModelService) []corev1.EnvVar
+
+	// TemplateVars sets the template vars describing the model, such as ModelPath
+	TemplateVars(msvc *msv1alpha1.ModelService, vars *TemplateVars) error
+}
+
+// ArtifactDownloader is implemented by the sources whose model can be downloaded
+// into the model volume, by an init container or by the Job populating the model cache
+type ArtifactDownloader interface {
+	ArtifactSource
+
+	// AlwaysDownload returns true if the model is downloaded even when
+	// neither modelArtifacts.download nor modelArtifacts.cache is set
+	AlwaysDownload() bool
+
+	// DownloadDir returns the directory the model is downloaded to, under ModelStorageRoot
+	DownloadDir(msvc *msv1alpha1.ModelService) (string, error)
+
+	// DownloadCommand returns the quoted command downloading the model into $dir,
+	// a new directory of the pod. It may instead set dir to the directory holding
+	// the model, such as a snapshot of the Hugging Face cache
+	DownloadCommand(msvc *msv1alpha1.ModelService, download *msv1alpha1.ModelDownload) []string
+
+	// DownloadEnv returns the env vars of the download container
+	DownloadEnv(msvc *msv1alpha1.ModelService) []corev1.EnvVar
+
+	// DefaultDownloadImage returns the image of the download container when
+	// download.image is not set. If empty, the image of the first container
+	// mounting the model volume is used
+	DefaultDownloadImage() string
+}
+
+var (
+	artifactSourcesMu sync.RWMutex
+	artifactSources   = map[string]ArtifactSource{}
+)
+
+// RegisterArtifactSource registers source for the URIs of its scheme.
+// It returns an error if the scheme is invalid or already registered
+func RegisterArtifactSource(source ArtifactSource) error {
+	scheme := source.Scheme()
+	if scheme == "" || strings.Contains(scheme, "://") {
+		return fmt.Errorf("invalid artifact source scheme %q", scheme)
+	}
+
+	artifactSourcesMu.Lock()
+	defer artifactSourcesMu.Unlock()
+	if _, ok := artifactSources[scheme]; ok {
+		return fmt.Errorf("artifact source for scheme %q is already registered", scheme)
+	}
+	artifactSources[scheme] = source
+	return nil
+}
+
+// mustRegisterArtifactSource registers the artifact sources built in the controller
+func mustRegisterArtifactSource(source ArtifactSource) {
+	if err := RegisterArtifactSource(source); err != nil {
+		panic(err)
+	}
+}
+
+// lookupArtifactSource returns the source registered for the scheme of uri
+func lookupArtifactSource(uri string) (ArtifactSource, bool) {
+	scheme, _, ok := strings.Cut(uri, "://")
+	if !ok {
+		return nil, false
+	}
+
+	artifactSourcesMu.RLock()
+	defer artifactSourcesMu.RUnlock()
+	source, ok := artifactSources[scheme]
+	return source, ok
+}
+
+// lookupArtifactDownloader returns the source registered for the scheme of uri
+// if its model can be downloaded
+func lookupArtifactDownloader(uri string) (ArtifactDownloader, bool) {
+	source, ok := lookupArtifactSource(uri)
+	if !ok {
+		return nil, false
+	}
+	downloader, ok := source.(ArtifactDownloader)
+	return downloader, ok
+}
+
+// unsupportedURIError returns the error of field not being supported by the scheme of uri
+func unsupportedURIError(field, uri string) error {
+	if scheme, _, ok := strings.Cut(uri, "://"); ok {
+		return fmt.Errorf("%s is not supported for %s:// URIs", field, scheme)
+	}
+	return fmt.Errorf("%s is not supported for uri %q", field, uri)
+}
+
+// modelStorageMount returns the mount of the model volume at ModelStorageRoot
+func modelStorageMount(readOnly bool) []corev1.VolumeMount {
+	return []corev1.VolumeMount{{
+		Name:      ModelStorageVolumeName,
+		MountPath: ModelStorageRoot,
+		ReadOnly:  readOnly,
+	}}
+}
+
+// downloadedModelVolumes returns the volume the model of a downloader is downloaded to:
+// the claim owned by msvc if the model is cached, download.cacheClaimName if set,
+// or an emptyDir of modelArtifacts.size
+func downloadedModelVolumes(downloader ArtifactDownloader, msvc *msv1alpha1.ModelService) []corev1.Volume {
+	if _, err := downloader.DownloadDir(msvc); err != nil {
+		return nil
+	}
+
+	artifacts := msvc.Spec.ModelArtifacts
+	volume := corev1.Volume{Name: ModelStorageVolumeName}
+	if artifacts.Cache != nil {
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: ModelCachePVCName(msvc),
+			ReadOnly:  true,
+		}
+	} else if artifacts.Download != nil && artifacts.Download.CacheClaimName != "" {
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: artifacts.Download.CacheClaimName,
+		}
+	} else {
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{
+			SizeLimit: artifacts.Size,
+		}
+	}
+	return []corev1.Volume{volume}
+}
@@ -0,0 +1,138 @@
+package render
+
+import (
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nfsSource is an artifact source registered in-process, nfs://<server>/<path>
+type nfsSource struct{}
+
+func (nfsSource) Scheme() string { return "nfs" }
+
+func (nfsSource) Parse(artifacts *msv1alpha1.ModelArtifacts) error { return nil }
+
+func (nfsSource) MountedModelPath(msvc *msv1alpha1.ModelService) (string, error) {
+	return ModelStorageRoot, nil
+}
+
+func (nfsSource) Volumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	return []corev1.Volume{{
+		Name:         ModelStorageVolumeName,
+		VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs.example.com", Path: "/models", ReadOnly: true}},
+	}}
+}
+
+func (nfsSource) VolumeMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	return modelStorageMount(true)
+}
+
+func (nfsSource) Env(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	return []corev1.EnvVar{{Name: "MODEL_SOURCE", Value: "nfs"}}
+}
+
+func (nfsSource) TemplateVars(msvc *msv1alpha1.ModelService, vars *TemplateVars) error {
+	vars.ModelPath = "models"
+	return nil
+}
+
+func TestRegisterArtifactSource(t *testing.T) {
+	require.NoError(t, RegisterArtifactSource(nfsSource{}))
+	t.Cleanup(func() {
+		artifactSourcesMu.Lock()
+		defer artifactSourcesMu.Unlock()
+		delete(artifactSources, "nfs")
+	})
+
+	assert.ErrorContains(t, RegisterArtifactSource(nfsSource{}), `scheme "nfs" is already registered`)
+	assert.ErrorContains(t, RegisterArtifactSource(hfSource{}), `scheme "hf" is already registered`)
+
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts.URI = "nfs://nfs.example.com/models"
+	assert.Equal(t, URIType("nfs"), UriType(msvc.Spec.ModelArtifacts.URI))
+
+	require.Len(t, getVolumeForPDDeployment(msvc), 1)
+	assert.NotNil(t, getVolumeForPDDeployment(msvc)[0].NFS)
+	assert.Equal(t, modelStorageMount(true), getVolumeMountsForContainer(msvc))
+	assert.Equal(t, []corev1.EnvVar{{Name: "MODEL_SOURCE", Value: "nfs"}}, getEnvsForContainer(msvc))
+
+	vars := &TemplateVars{}
+	require.NoError(t, vars.from(msvc))
+	assert.Equal(t, "models", vars.ModelPath)
+	assert.Equal(t, ModelStorageRoot, vars.MountedModelPath)
+
+	// the source cannot be downloaded
+	msvc.Spec.ModelArtifacts.Download = &msv1alpha1.ModelDownload{Image: "python:3.12"}
+	_, err := getModelDownloadInitContainer(msvc, &msv1alpha1.PDSpec{})
+	assert.ErrorContains(t, err, "modelArtifacts.download is not supported for nfs:// URIs")
+}
+
+func TestRegisterArtifactSourceInvalidScheme(t *testing.T) {
+	for _, scheme := range []string{"", "nfs://"} {
+		err := RegisterArtifactSource(objectStorageSource{scheme: scheme})
+		assert.ErrorContains(t, err, "invalid artifact source scheme", scheme)
+	}
+}
+
+func TestOCIArtifactSource(t *testing.T) {
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts.URI = "oci://quay.io/llm-d/granite:3.3"
+
+	volumes := getVolumeForPDDeployment(msvc)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, &corev1.ImageVolumeSource{Reference: "quay.io/llm-d/granite:3.3", PullPolicy: corev1.PullIfNotPresent}, volumes[0].Image)
+	assert.Equal(t, modelStorageMount(true), getVolumeMountsForContainer(msvc))
+	assert.Empty(t, getEnvsForContainer(msvc))
+
+	vars := &TemplateVars{}
+	require.NoError(t, vars.from(msvc))
+	assert.Empty(t, vars.ModelPath)
+	assert.Equal(t, ModelStorageRoot, vars.MountedModelPath)
+
+	msvc.Spec.ModelArtifacts.URI = "oci://quay.io/llm-d/models:v1::granite/3.3"
+	require.NoError(t, vars.from(msvc))
+	assert.Equal(t, "quay.io/llm-d/models:v1", getVolumeForPDDeployment(msvc)[0].Image.Reference)
+	assert.Equal(t, "granite/3.3", vars.ModelPath)
+	assert.Equal(t, ModelStorageRoot+"/granite/3.3", vars.MountedModelPath)
+
+	msvc.Spec.ModelArtifacts.URI = "oci://"
+	assert.Empty(t, getVolumeForPDDeployment(msvc))
+	assert.ErrorContains(t, (&TemplateVars{}).from(msvc), "need oci://<image-repo>/<image-name>:<tag>")
+}
+
+// every built in source must mount the volume it declares, and compute
+// the template vars of a valid URI
+func TestArtifactSourcesConsistency(t *testing.T) {
+	for _, uri := range []string{
+		"hf://facebook/opt-125m",
+		"pvc://my-pvc/path/to/model",
+		"oci://quay.io/llm-d/granite:3.3",
+		"s3://models/llama/3.1-8b",
+		"gs://models/opt-125m",
+	} {
+		t.Run(uri, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.ModelArtifacts.URI = uri
+
+			volumes := getVolumeForPDDeployment(msvc)
+			require.Len(t, volumes, 1)
+			mounts := getVolumeMountsForContainer(msvc)
+			require.Len(t, mounts, 1)
+			assert.Equal(t, volumes[0].Name, mounts[0].Name)
+
+			vars := &TemplateVars{}
+			require.NoError(t, vars.from(msvc))
+			modelPath, err := mountedModelPath(msvc)
+			require.NoError(t, err)
+			assert.Equal(t, modelPath, vars.MountedModelPath)
+		})
+	}
+
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts.URI = "hf://opt-125m"
+	assert.ErrorContains(t, (&TemplateVars{}).from(msvc), "need hf://<repo-id>/<model-id>")
+}
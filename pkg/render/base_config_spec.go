@@ -0,0 +1,99 @@
+package render
+
+import (
+	"fmt"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigMapFromBaseConfigSpec returns the base config ConfigMap equivalent to
+// spec, so that ModelServiceBaseConfig and ClusterModelServiceBaseConfig go
+// through the same interpolation and decoding as a ConfigMap.
+// The ConfigMap takes the name and namespace of meta
+func ConfigMapFromBaseConfigSpec(meta metav1.ObjectMeta, spec *msv1alpha1.BaseConfigSpec) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+		},
+		Data: map[string]string{},
+	}
+
+	// typed fields are serialized as is; nil fields are skipped
+	typed := map[string]interface{}{}
+	if spec.Parent != nil {
+		typed[parentKey] = spec.Parent
+	}
+	if len(spec.TemplateVars) > 0 {
+		typed[templateVarsKey] = spec.TemplateVars
+	}
+	if len(spec.Overlays) > 0 {
+		typed[overlaysKey] = spec.Overlays
+	}
+	if spec.Propagation != nil {
+		typed[propagationKey] = spec.Propagation
+	}
+	if len(spec.ConfigMaps) > 0 {
+		typed["configMaps"] = spec.ConfigMaps
+	}
+	if spec.PrefillService != nil {
+		typed["prefillService"] = spec.PrefillService
+	}
+	if spec.DecodeService != nil {
+		typed["decodeService"] = spec.DecodeService
+	}
+	if spec.InferencePool != nil {
+		typed["inferencePool"] = spec.InferencePool
+	}
+	if spec.InferenceModel != nil {
+		typed["inferenceModel"] = spec.InferenceModel
+	}
+	if spec.EPPService != nil {
+		typed["eppService"] = spec.EPPService
+	}
+	if spec.EPPServiceAccount != nil {
+		typed["eppServiceAccount"] = spec.EPPServiceAccount
+	}
+	if spec.PDServiceAccount != nil {
+		typed["pdServiceAccount"] = spec.PDServiceAccount
+	}
+	if spec.EPPRoleBinding != nil {
+		typed["eppRoleBinding"] = spec.EPPRoleBinding
+	}
+	for key, value := range typed {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, &DecodeError{Key: key, Err: err}
+		}
+		cm.Data[key] = string(data)
+	}
+
+	// schemaless fields hold the json stored by the API server
+	raw := map[string]*runtime.RawExtension{
+		"prefillDeployment": spec.PrefillDeployment,
+		"decodeDeployment":  spec.DecodeDeployment,
+		"eppDeployment":     spec.EPPDeployment,
+		"httpRoute":         spec.HTTPRoute,
+		"podMonitor":        spec.PodMonitor,
+	}
+	for key, value := range raw {
+		if value == nil || len(value.Raw) == 0 {
+			continue
+		}
+		data, err := yaml.JSONToYAML(value.Raw)
+		if err != nil {
+			return nil, &DecodeError{Key: key, Err: fmt.Errorf("invalid json: %w", err)}
+		}
+		cm.Data[key] = string(data)
+	}
+
+	return cm, nil
+}
@@ -0,0 +1,55 @@
+package render
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestConfigMapFromBaseConfigSpec(t *testing.T) {
+	spec := &msv1alpha1.BaseConfigSpec{
+		DecodeDeployment: &runtime.RawExtension{
+			Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"vllm","args":["{{ .ModelPath }}"]}]}}}}`),
+		},
+		DecodeService: &corev1.Service{
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "vllm", Port: 8000}},
+			},
+		},
+		PDServiceAccount: &corev1.ServiceAccount{
+			AutomountServiceAccountToken: ptr.To(false),
+		},
+	}
+
+	cm, err := ConfigMapFromBaseConfigSpec(metav1.ObjectMeta{Name: "base", Namespace: "ns"}, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "base", cm.Name)
+	assert.Equal(t, "ns", cm.Namespace)
+	assert.ElementsMatch(t, []string{"decodeDeployment", "decodeService", "pdServiceAccount"}, slices.Collect(maps.Keys(cm.Data)))
+
+	// the converted ConfigMap is interpolated and decoded like any other base config
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{})
+	bc, err := LoadBaseConfig(t.Context(), cm, msvc)
+	require.NoError(t, err)
+
+	require.NotNil(t, bc.DecodeDeployment)
+	assert.Equal(t, []string{modelPath}, bc.DecodeDeployment.Spec.Template.Spec.Containers[0].Args)
+	require.NotNil(t, bc.DecodeService)
+	assert.Equal(t, int32(8000), bc.DecodeService.Spec.Ports[0].Port)
+	require.NotNil(t, bc.PDServiceAccount)
+	assert.False(t, *bc.PDServiceAccount.AutomountServiceAccountToken)
+}
+
+func TestConfigMapFromBaseConfigSpecEmpty(t *testing.T) {
+	cm, err := ConfigMapFromBaseConfigSpec(metav1.ObjectMeta{Name: "base"}, &msv1alpha1.BaseConfigSpec{})
+	require.NoError(t, err)
+	assert.Empty(t, cm.Data)
+}
@@ -0,0 +1,947 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"dario.cat/mergo"
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/tracing"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	giev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ChildResources holds every object a ModelService spawns.
+// The same struct is used for the decoded base config, before the
+// ModelService is merged into it, and for the final child resources
+type ChildResources struct {
+	ConfigMaps        []corev1.ConfigMap          `json:"configMaps,omitempty"`
+	PrefillDeployment *appsv1.Deployment          `json:"prefillDeployment,omitempty"`
+	DecodeDeployment  *appsv1.Deployment          `json:"decodeDeployment,omitempty"`
+	PrefillService    *corev1.Service             `json:"prefillService,omitempty"`
+	DecodeService     *corev1.Service             `json:"decodeService,omitempty"`
+	HTTPRoute         *gatewayv1.HTTPRoute        `json:"httpRoute,omitempty"`
+	InferencePool     *giev1alpha2.InferencePool  `json:"inferencePool,omitempty"`
+	InferenceModel    *giev1alpha2.InferenceModel `json:"inferenceModel,omitempty"`
+	EPPDeployment     *appsv1.Deployment          `json:"eppDeployment,omitempty"`
+	EPPService        *corev1.Service             `json:"eppService,omitempty"`
+	EPPServiceAccount *corev1.ServiceAccount      `json:"eppServiceAccount,omitempty"`
+	PDServiceAccount  *corev1.ServiceAccount      `json:"pdServiceAccount,omitempty"`
+	EPPRoleBinding    *rbacv1.RoleBinding         `json:"eppRoleBinding,omitempty"`
+	PodMonitor        *monitoringv1.PodMonitor    `json:"podMonitor,omitempty"`
+	// ModelCachePVC and ModelDownloadJob are only rendered from the ModelService
+	ModelCachePVC    *corev1.PersistentVolumeClaim `json:"modelCachePVC,omitempty"`
+	ModelDownloadJob *batchv1.Job                  `json:"modelDownloadJob,omitempty"`
+	// PrefillAcceleratorDeployments and DecodeAcceleratorDeployments hold the Deployments
+	// of the accelerator overrides of each role. They are only rendered from the ModelService
+	PrefillAcceleratorDeployments []*appsv1.Deployment `json:"prefillAcceleratorDeployments,omitempty"`
+	DecodeAcceleratorDeployments  []*appsv1.Deployment `json:"decodeAcceleratorDeployments,omitempty"`
+}
+
+// BaseConfig holds information read from the base configmap
+type BaseConfig = ChildResources
+
+// ShouldCreateConfigMaps returns True if there is at least one ConfigMap to be created
+func (childResource *ChildResources) ShouldCreateConfigMaps() bool {
+	return len(childResource.ConfigMaps) > 0
+}
+
+// ShouldCreatePrefillDeployment returns True if the prefill deployment needs to be created
+func (childResource *ChildResources) ShouldCreatePrefillDeployment() bool {
+	return childResource.PrefillDeployment != nil
+}
+
+// ShouldCreatePrefillService returns True if the prefill deployment needs to be created
+func (childResource *ChildResources) ShouldCreatePrefillService() bool {
+	return childResource.ShouldCreatePrefillDeployment() && childResource.PrefillService != nil
+}
+
+// ShouldCreateDecodeDeployment returns True if the decode deployment needs to be created
+func (childResource *ChildResources) ShouldCreateDecodeDeployment() bool {
+	return childResource.DecodeDeployment != nil
+}
+
+// ShouldCreateDecodeService returns True if the decode deployment needs to be created
+func (childResource *ChildResources) ShouldCreateDecodeService() bool {
+	return childResource.ShouldCreateDecodeDeployment() && childResource.DecodeService != nil
+}
+
+// PDDeployments returns the prefill and decode deployments to be created,
+// including the deployments of the accelerator overrides
+func (childResource *ChildResources) PDDeployments() []*appsv1.Deployment {
+	var deployments []*appsv1.Deployment
+	if childResource.ShouldCreatePrefillDeployment() {
+		deployments = append(deployments, childResource.PrefillDeployment)
+	}
+	deployments = append(deployments, childResource.PrefillAcceleratorDeployments...)
+	if childResource.ShouldCreateDecodeDeployment() {
+		deployments = append(deployments, childResource.DecodeDeployment)
+	}
+	return append(deployments, childResource.DecodeAcceleratorDeployments...)
+}
+
+// ShouldCreatePDServiceAccountreturns True if either prefill or decode deployment needs to be created
+func (childResource *ChildResources) ShouldCreatePDServiceAccount() bool {
+	return childResource.ShouldCreatePrefillDeployment() || childResource.ShouldCreateDecodeDeployment()
+}
+
+// ShouldCreateEPPDeployment returns True if the EPP deployment needs to be created
+func (childResource *ChildResources) ShouldCreateEPPDeployment() bool {
+	return childResource.EPPDeployment != nil
+}
+
+// ShouldCreateEPPService returns True if the EPP deployment needs to be created
+func (childResource *ChildResources) ShouldCreateEPPService() bool {
+	return childResource.ShouldCreateEPPDeployment() && childResource.EPPService != nil
+}
+
+// ShouldCreateEPPServiceAccount returns True if EPP deployment needs to be created
+func (childResource *ChildResources) ShouldCreateEPPServiceAccount() bool {
+	return childResource.ShouldCreateEPPDeployment() && childResource.EPPServiceAccount != nil
+}
+
+// ShouldCreateEPPRoleBinding returns True if EPP deployment needs to be created
+func (childResource *ChildResources) ShouldCreateEPPRoleBinding() bool {
+	return childResource.ShouldCreateEPPDeployment() && childResource.EPPRoleBinding != nil
+}
+
+// ShouldCreateHTTPRoute returns True if HTTPRoute needs to be created
+// should be created if there's an InferencePool or if HTTPRoute is specified in the baseconfig
+func (childResource *ChildResources) ShouldCreateHTTPRoute() bool {
+	return childResource.HTTPRoute != nil
+}
+
+// ShouldCreateInferencePool returns True if InferencePool needs to be created
+func (childResource *ChildResources) ShouldCreateInferencePool() bool {
+	return childResource.InferencePool != nil
+}
+
+// ShouldCreateInferenceModel returns True if InferenceModel needs to be created
+func (childResource *ChildResources) ShouldCreateInferenceModel() bool {
+	return childResource.InferenceModel != nil
+}
+
+// ShouldCreatePodMonitor returns True if the PodMonitor needs to be created
+func (childResource *ChildResources) ShouldCreatePodMonitor() bool {
+	return childResource.PodMonitor != nil
+}
+
+// ShouldCreateModelCache returns True if the model cache PVC and its download Job need to be created
+func (childResource *ChildResources) ShouldCreateModelCache() bool {
+	return childResource.ModelCachePVC != nil && childResource.ModelDownloadJob != nil
+}
+
+// baseConfigKeys lists the keys a base config ConfigMap may hold, in the
+// order they are decoded
+var baseConfigKeys = []string{
+	"configMaps",
+	"prefillDeployment",
+	"decodeDeployment",
+	"prefillService",
+	"decodeService",
+	"httpRoute",
+	"inferencePool",
+	"inferenceModel",
+	"eppDeployment",
+	"eppService",
+	"eppServiceAccount",
+	"pdServiceAccount",
+	"eppRoleBinding",
+	"podMonitor",
+}
+
+// baseConfigHeaderKeys lists the keys configuring how the base config is
+// resolved and rendered, rather than holding a resource
+var baseConfigHeaderKeys = []string{parentKey, templateVarsKey, overlaysKey, propagationKey}
+
+// BaseConfigFromCM returns a BaseConfig object if the input
+// configmap is a valid serialization.
+// Every key is decoded strictly, so unknown or duplicate fields are errors.
+// All decoding failures are returned together as DecodeErrors
+func BaseConfigFromCM(cm *corev1.ConfigMap) (*BaseConfig, error) {
+	// populate baseconfig struct
+	bc := &BaseConfig{}
+
+	targets := map[string]interface{}{
+		"configMaps":        &bc.ConfigMaps,
+		"prefillDeployment": &bc.PrefillDeployment,
+		"decodeDeployment":  &bc.DecodeDeployment,
+		"prefillService":    &bc.PrefillService,
+		"decodeService":     &bc.DecodeService,
+		"httpRoute":         &bc.HTTPRoute,
+		"inferencePool":     &bc.InferencePool,
+		"inferenceModel":    &bc.InferenceModel,
+		"eppDeployment":     &bc.EPPDeployment,
+		"eppService":        &bc.EPPService,
+		"eppServiceAccount": &bc.EPPServiceAccount,
+		"pdServiceAccount":  &bc.PDServiceAccount,
+		"eppRoleBinding":    &bc.EPPRoleBinding,
+		"podMonitor":        &bc.PodMonitor,
+	}
+
+	var errs DecodeErrors
+	for _, key := range baseConfigKeys {
+		raw, ok := cm.Data[key]
+		if !ok || strings.TrimSpace(raw) == "" {
+			continue
+		}
+		if err := yaml.UnmarshalStrict([]byte(raw), targets[key]); err != nil {
+			errs = append(errs, &DecodeError{Key: key, Line: errorLine(raw, err), Err: err})
+		}
+	}
+
+	// the parent is resolved by the caller; only check that it is valid here
+	var keyErr *DecodeError
+	if _, err := ParentRef(cm); errors.As(err, &keyErr) {
+		errs = append(errs, keyErr)
+	}
+
+	// template variables and overlays are applied before decoding; only check that they are valid here
+	if _, err := TemplateVarDeclarations(cm); errors.As(err, &keyErr) {
+		errs = append(errs, keyErr)
+	}
+	if _, err := Overlays(cm); errors.As(err, &keyErr) {
+		errs = append(errs, keyErr)
+	}
+
+	// the propagation policy is read by the controller; only check that it is valid here
+	if _, err := Propagation(cm); errors.As(err, &keyErr) {
+		errs = append(errs, keyErr)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return bc, nil
+}
+
+// UnknownBaseConfigKeys returns the sorted top-level keys of cm that are
+// not base config keys. Such keys are ignored by BaseConfigFromCM and are
+// most likely typos
+func UnknownBaseConfigKeys(cm *corev1.ConfigMap) []string {
+	if cm == nil {
+		return nil
+	}
+
+	var unknown []string
+	for key := range cm.Data {
+		if !slices.Contains(baseConfigHeaderKeys, key) && !slices.Contains(baseConfigKeys, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+
+	return unknown
+}
+
+// MergeChildResources merges the MSVC resources into BaseConfig resources
+// merging means MSVC controller is overwriting some fields, such as Name and Namespace for that resource
+func (interpolatedBaseConfig *BaseConfig) MergeChildResources(ctx context.Context, modelService *msv1alpha1.ModelService, scheme *runtime.Scheme, rbacOptions *RBACOptions) (_ *ChildResources, err error) {
+	ctx, span := tracer.Start(ctx, "MergeChildResources")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Step: update configmaps
+	if interpolatedBaseConfig.ConfigMaps != nil {
+		if err := interpolatedBaseConfig.mergeConfigMaps(modelService, scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 3: update the child resources
+	// Idea: updates do the mergo merge
+	if modelService.Spec.Prefill != nil || interpolatedBaseConfig.PrefillDeployment != nil {
+		if err := interpolatedBaseConfig.mergePDDeployment(ctx, modelService, PREFILL_ROLE, scheme); err != nil {
+			return nil, err
+		}
+		if interpolatedBaseConfig.PrefillService != nil {
+			if err := interpolatedBaseConfig.mergePDService(ctx, modelService, PREFILL_ROLE, scheme); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if modelService.Spec.Decode != nil || interpolatedBaseConfig.DecodeDeployment != nil {
+		if err := interpolatedBaseConfig.mergePDDeployment(ctx, modelService, DECODE_ROLE, scheme); err != nil {
+			return nil, err
+		}
+		if interpolatedBaseConfig.DecodeService != nil {
+			if err := interpolatedBaseConfig.mergePDService(ctx, modelService, DECODE_ROLE, scheme); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the download image defaults to the image of the merged deployments
+	if err := interpolatedBaseConfig.mergeModelCache(ctx, modelService, scheme); err != nil {
+		return nil, err
+	}
+
+	if interpolatedBaseConfig.PrefillDeployment != nil || interpolatedBaseConfig.DecodeDeployment != nil {
+		// some pd pods are getting created; set SA and RB here
+		if err := interpolatedBaseConfig.setPDServiceAccount(modelService, scheme, rbacOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	if interpolatedBaseConfig.HTTPRoute != nil || len(modelService.Spec.Routing.GatewayRefs) > 0 {
+		if err := interpolatedBaseConfig.mergeHTTPRoute(modelService, scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	if interpolatedBaseConfig.InferencePool != nil {
+		if err := interpolatedBaseConfig.mergeInferencePool(ctx, modelService, scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	if interpolatedBaseConfig.InferenceModel != nil {
+		if err := interpolatedBaseConfig.mergeInferenceModel(ctx, modelService, scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	if interpolatedBaseConfig.PodMonitor != nil || modelService.Spec.Monitoring != nil {
+		if err := interpolatedBaseConfig.mergePodMonitor(ctx, modelService, scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	if interpolatedBaseConfig.EPPDeployment != nil {
+		if err := interpolatedBaseConfig.mergeEppDeployment(modelService, scheme); err != nil {
+			return nil, err
+		}
+		if interpolatedBaseConfig.EPPService != nil {
+			if err := interpolatedBaseConfig.mergeEppService(modelService, scheme); err != nil {
+				return nil, err
+			}
+		}
+		if err := interpolatedBaseConfig.setEPPServiceAccount(modelService, rbacOptions, scheme); err != nil {
+			return nil, err
+		}
+		// this is role binding with a cluster role
+		if err := interpolatedBaseConfig.setEPPRoleBinding(modelService, rbacOptions, scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	return interpolatedBaseConfig, nil
+}
+
+// mergeConfigMaps creates config maps for found in base config
+func (childResource *ChildResources) mergeConfigMaps(msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+	for i := range childResource.ConfigMaps {
+		childResource.ConfigMaps[i].APIVersion = "v1"
+		childResource.ConfigMaps[i].Kind = "ConfigMap"
+		// if there's no namespace, set it to msvc's
+		if strings.TrimSpace(childResource.ConfigMaps[i].Namespace) == "" {
+			childResource.ConfigMaps[i].Namespace = msvc.Namespace
+		}
+		// Note: there seems to be a controllerutil bug here ...
+		// Setting owner ref before setting namespace seems problematic
+		if err := controllerutil.SetOwnerReference(msvc, &childResource.ConfigMaps[i], scheme); err != nil {
+			return &MergeError{Kind: "ConfigMap", Name: childResource.ConfigMaps[i].Name, Err: err}
+		}
+	}
+	return nil
+}
+
+// getCommonLabels that are applicable to all resources owned by msvc
+func getCommonLabels(ctx context.Context, msvc *msv1alpha1.ModelService) map[string]string {
+	// Step 3: Define object meta
+	// Sanitize modelName into a valid label
+	// TODO: this is not a good approach. Confirm with routing team on what label they need
+	// An unsanitizable model name results in an empty label value
+	modelLabel, _ := sanitizeName(msvc.Spec.Routing.ModelName)
+
+	return map[string]string{
+		"llm-d.ai/inferenceServing": "true",
+		"llm-d.ai/model":            modelLabel,
+	}
+}
+
+// getPodLabels adds a role on top of the common labels
+func getPodLabels(ctx context.Context, msvc *msv1alpha1.ModelService, role string) map[string]string {
+	labels := getCommonLabels(ctx, msvc)
+	labels["llm-d.ai/role"] = role
+	return labels
+}
+
+// mergeInferenceModel uses msvc fields to update childResource inference model
+func (childResources *ChildResources) mergeInferenceModel(ctx context.Context, msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+	// there's nothing to update
+	if childResources.InferenceModel == nil {
+		return nil
+	}
+
+	im := childResources.InferenceModel
+
+	im.APIVersion = "inference.networking.x-k8s.io/v1alpha2"
+	im.Kind = "InferenceModel"
+	im.Name = InferenceModelName(msvc)
+	im.Namespace = msvc.Namespace
+	im.Labels = getCommonLabels(ctx, msvc)
+	im.Spec.ModelName = msvc.Spec.Routing.ModelName
+	im.Spec.PoolRef.Name = giev1alpha2.ObjectName(InferencePoolName(msvc))
+
+	// Set owner reference for the merged service
+	if err := controllerutil.SetOwnerReference(msvc, im, scheme); err != nil {
+		return &MergeError{Kind: "InferenceModel", Name: im.Name, Err: err}
+	}
+
+	return nil
+}
+
+// sanitizeSvcName returns the
+func sanitizeSvcName(msvc *msv1alpha1.ModelService, role string) string {
+	sanitizedName, err := sanitizeName(msvc.Name + "-service-" + role)
+	if err != nil {
+		// TODO: don't return a default name?
+		return "default-service-" + role
+	}
+
+	return sanitizedName
+}
+
+func sanitizeModelName(msvc *msv1alpha1.ModelService) string {
+	sanitizedModelName, err := sanitizeName(msvc.Spec.Routing.ModelName)
+	if err != nil {
+		// TODO: don't return a default model name?
+		return "default-modelName"
+	}
+
+	return sanitizedModelName
+}
+
+// mergePDService uses msvc fields to update childResource P/D Service
+func (childResource *ChildResources) mergePDService(ctx context.Context, msvc *msv1alpha1.ModelService, role string, scheme *runtime.Scheme) error {
+
+	// Get dest Service
+	destService := corev1.Service{}
+
+	if role == PREFILL_ROLE {
+		if childResource.PrefillService != nil {
+			destService = *childResource.PrefillService
+
+		} else {
+			// prefillService is not specified in baseConfig, so we are not going to create a service. Return
+			return nil
+		}
+	} else {
+		if childResource.DecodeService != nil {
+			destService = *childResource.DecodeService
+		} else {
+			// decodeService is not specified in baseConfig, so we are not going to create a service. Return
+			return nil
+		}
+	}
+
+	destService.APIVersion = "v1"
+	destService.Kind = "Service"
+
+	// At this point, we are going to create a service for role
+	// srcService contains ownerRef, name for service, and selector labels
+	// srcService contains the stuff we want to override destService with
+
+	srcService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sanitizeSvcName(msvc, role),
+			Namespace: msvc.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			// If destService contains labels, it will do a override based on key
+			// otherwise, just add to the map
+			Selector: getPodLabels(ctx, msvc, role),
+		},
+	}
+
+	// Mergo merge src into dst
+	if err := mergo.Merge(&destService, srcService, mergo.WithOverride); err != nil {
+		return &MergeError{Kind: "Service", Name: srcService.Name, Err: err}
+	}
+
+	// Set owner reference for the merged service
+	if err := controllerutil.SetOwnerReference(msvc, &destService, scheme); err != nil {
+		return &MergeError{Kind: "Service", Name: srcService.Name, Err: err}
+	}
+
+	// Set the merged service for child resource
+	if role == PREFILL_ROLE {
+		childResource.PrefillService = &destService
+	} else {
+		childResource.DecodeService = &destService
+	}
+
+	return nil
+}
+
+// mergePDDeployment uses msvc fields to update childResource prefill deployment
+func (childResource *ChildResources) mergePDDeployment(ctx context.Context, msvc *msv1alpha1.ModelService, role string, scheme *runtime.Scheme) error {
+	pdSpec := &msv1alpha1.PDSpec{}
+	if role == PREFILL_ROLE {
+		if msvc.Spec.Prefill != nil {
+			pdSpec = msvc.Spec.Prefill
+		}
+		if childResource.PrefillDeployment == nil {
+			childResource.PrefillDeployment = &appsv1.Deployment{}
+		}
+	}
+	if role == DECODE_ROLE {
+		if msvc.Spec.Decode != nil {
+			pdSpec = msvc.Spec.Decode
+		}
+		if childResource.DecodeDeployment == nil {
+			childResource.DecodeDeployment = &appsv1.Deployment{}
+		}
+	}
+
+	name := DeploymentName(msvc, role)
+
+	// Compute fields needed
+	podLabels := getPodLabels(ctx, msvc, role)
+
+	// affinity and topology spread constraints are merged with the base config after mergo
+	affinity, err := pdAffinity(ctx, msvc, pdSpec, role)
+	if err != nil {
+		return &MergeError{Kind: "Deployment", Name: name, Err: err}
+	}
+
+	// the model is downloaded, then verified, before any other init container runs
+	downloadContainer, err := getModelDownloadInitContainer(msvc, pdSpec)
+	if err != nil {
+		return &MergeError{Kind: "Deployment", Name: name, Err: err}
+	}
+	verifyContainers, err := getModelVerificationInitContainers(msvc)
+	if err != nil {
+		return &MergeError{Kind: "Deployment", Name: name, Err: err}
+	}
+	var initContainers []corev1.Container
+	if downloadContainer != nil {
+		initContainers = append(initContainers, *downloadContainer)
+	}
+	initContainers = append(initContainers, verifyContainers...)
+	initContainers = append(initContainers, convertToContainerSliceWithURIInfo(pdSpec.InitContainers, msvc)...)
+
+	// Step 1: Create an empty deployment
+	desiredDeployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: msvc.Namespace,
+
+			// Define the labels for this PD deployment
+			// Same as pod labels
+			Labels: podLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			// Define template selector labels
+			// The pods of the accelerator overrides of the role have the same
+			// labels, plus AcceleratorLabel
+			Selector: &metav1.LabelSelector{
+				MatchLabels: podLabels,
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: AcceleratorLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+
+			// Define replicas
+			// Decouple scaling will be handled in the merge
+			Replicas: pdSpec.Replicas,
+
+			// Define pod templates with our templates
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					// populate containers
+					InitContainers: initContainers,
+					Containers:     convertToContainerSliceWithURIInfo(pdSpec.Containers, msvc),
+
+					// populate service account for PD pods
+					ServiceAccountName: PDServiceAccountName(msvc),
+
+					// populate volumes based on URI
+					Volumes: getVolumeForPDDeployment(msvc),
+				},
+			},
+		},
+	}
+
+	// Define pod labels, must match selector labels, and the pod-level fields of the ModelService
+	setPodTemplateFields(&desiredDeployment.Spec.Template, &pdSpec.ModelServicePodSpec, placementPodLabels(ctx, msvc, role))
+
+	// Finally, set owner references
+	if err := controllerutil.SetOwnerReference(msvc, desiredDeployment, scheme); err != nil {
+		return &MergeError{Kind: "Deployment", Name: name, Err: err}
+	}
+
+	// Finally, in Mergo merge...
+	// We create a destination deployment object from baseconfig
+	// We create a source deployment object from model service
+	// We merge source into destination
+	// We apply the merged destination
+
+	var originalDeployment *appsv1.Deployment
+
+	if role == PREFILL_ROLE {
+		originalDeployment = childResource.PrefillDeployment
+	}
+	if role == DECODE_ROLE {
+		originalDeployment = childResource.DecodeDeployment
+	}
+
+	// mergo appends node selector terms, which are ORed, so the base config
+	// affinity is merged separately
+	baseAffinity := originalDeployment.Spec.Template.Spec.Affinity.DeepCopy()
+	baseConstraints := slices.Clone(originalDeployment.Spec.Template.Spec.TopologySpreadConstraints)
+
+	// Mergo merge
+	if err := mergo.Merge(
+		originalDeployment,
+		desiredDeployment,
+		mergo.WithOverride,
+		mergo.WithAppendSlice,
+		mergo.WithTransformers(podTemplateTransformers)); err != nil {
+		return &MergeError{Kind: "Deployment", Name: name, Err: err}
+	}
+
+	podSpec := &originalDeployment.Spec.Template.Spec
+	podSpec.Affinity = mergeAffinity(baseAffinity, affinity)
+	podSpec.TopologySpreadConstraints = mergeTopologySpreadConstraints(baseConstraints, topologySpreadConstraints(ctx, msvc, role))
+
+	return nil
+}
+
+// setPDServiceAccount defines a servicd account for the P and D deployments
+func (childResource *ChildResources) setPDServiceAccount(msvc *msv1alpha1.ModelService, scheme *runtime.Scheme, rbacOptions *RBACOptions) error {
+	// start from the base config service account, if any
+	sa := &corev1.ServiceAccount{}
+	if childResource.PDServiceAccount != nil {
+		sa = childResource.PDServiceAccount.DeepCopy()
+	}
+	sa.TypeMeta = metav1.TypeMeta{
+		Kind:       "ServiceAccount",
+		APIVersion: "v1",
+	}
+	sa.Name = PDServiceAccountName(msvc)
+	sa.Namespace = msvc.Namespace
+
+	for _, name := range rbacOptions.PDPullSecrets {
+		sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+
+	// Set owner reference for service account
+	if err := controllerutil.SetOwnerReference(msvc, sa, scheme); err != nil {
+		return &MergeError{Kind: "ServiceAccount", Name: sa.Name, Err: err}
+	}
+
+	childResource.PDServiceAccount = sa
+
+	return nil
+}
+
+func (childResource *ChildResources) setEPPServiceAccount(msvc *msv1alpha1.ModelService, rbacOptions *RBACOptions, scheme *runtime.Scheme) error {
+	// start from the base config service account, if any
+	eppServiceAccount := &corev1.ServiceAccount{}
+	if childResource.EPPServiceAccount != nil {
+		eppServiceAccount = childResource.EPPServiceAccount.DeepCopy()
+	}
+	eppServiceAccount.TypeMeta = metav1.TypeMeta{
+		Kind:       "ServiceAccount",
+		APIVersion: "v1",
+	}
+	eppServiceAccount.Name = EPPServiceAccountName(msvc)
+	eppServiceAccount.Namespace = msvc.Namespace
+
+	for _, name := range rbacOptions.EPPPullSecrets {
+		eppServiceAccount.ImagePullSecrets = append(eppServiceAccount.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+
+	if err := controllerutil.SetOwnerReference(msvc, eppServiceAccount, scheme); err != nil {
+		return &MergeError{Kind: "ServiceAccount", Name: eppServiceAccount.Name, Err: err}
+	}
+
+	childResource.EPPServiceAccount = eppServiceAccount
+
+	return nil
+}
+
+func (childResource *ChildResources) setEPPRoleBinding(msvc *msv1alpha1.ModelService, rbacOptions *RBACOptions, scheme *runtime.Scheme) error {
+
+	// start from the base config role binding, if any
+	roleBinding := &rbacv1.RoleBinding{}
+	if childResource.EPPRoleBinding != nil {
+		roleBinding = childResource.EPPRoleBinding.DeepCopy()
+	}
+	roleBinding.TypeMeta = metav1.TypeMeta{
+		Kind:       "RoleBinding",
+		APIVersion: "rbac.authorization.k8s.io/v1",
+	}
+	roleBinding.Name = EPPRoleBindingName(msvc)
+	roleBinding.Namespace = msvc.Namespace
+	roleBinding.Subjects = []rbacv1.Subject{
+		{
+			Kind:      "ServiceAccount",
+			APIGroup:  "",
+			Name:      EPPServiceAccountName(msvc),
+			Namespace: msvc.Namespace,
+		},
+	}
+	// the cluster role from the controller options wins over the base config
+	if rbacOptions.EPPClusterRole != "" || roleBinding.RoleRef.Name == "" {
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			Kind:     "ClusterRole",
+			APIGroup: "rbac.authorization.k8s.io",
+			Name:     rbacOptions.EPPClusterRole,
+		}
+	}
+
+	// Set owner reference for EPPRoleBinding
+	if err := controllerutil.SetOwnerReference(msvc, roleBinding, scheme); err != nil {
+		return &MergeError{Kind: "RoleBinding", Name: roleBinding.Name, Err: err}
+	}
+
+	childResource.EPPRoleBinding = roleBinding
+
+	return nil
+}
+
+func getInferencePoolLabels(ctx context.Context, msvc *msv1alpha1.ModelService) map[giev1alpha2.LabelKey]giev1alpha2.LabelValue {
+	commonLabels := getCommonLabels(ctx, msvc)
+	m := make(map[giev1alpha2.LabelKey]giev1alpha2.LabelValue, len(commonLabels))
+	for k, v := range commonLabels {
+		m[giev1alpha2.LabelKey(k)] = giev1alpha2.LabelValue(v)
+	}
+	return m
+}
+
+func (childResources *ChildResources) mergeEppDeployment(msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+
+	if childResources == nil || childResources.EPPDeployment == nil {
+		return nil
+	}
+
+	eppLabels := map[string]string{
+		"llm-d.ai/epp": EPPDeploymentName(msvc),
+	}
+	dest := *childResources.EPPDeployment
+
+	src := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EPPDeploymentName(msvc),
+			Namespace: msvc.Namespace,
+		},
+	}
+
+	src.Labels = eppLabels
+
+	src.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: eppLabels,
+	}
+
+	modelSrvPodSpec := &msv1alpha1.ModelServicePodSpec{}
+	if msvc.Spec.EndpointPicker != nil {
+		modelSrvPodSpec = msvc.Spec.EndpointPicker
+	}
+	setPodTemplateFields(&src.Spec.Template, modelSrvPodSpec, eppLabels)
+	src.Spec.Replicas = modelSrvPodSpec.Replicas
+	src.Spec.Template.Spec.Containers = convertToContainerSlice(modelSrvPodSpec.Containers)
+	src.Spec.Template.Spec.InitContainers = convertToContainerSlice(modelSrvPodSpec.InitContainers)
+
+	// set epp service account name
+	src.Spec.Template.Spec.ServiceAccountName = EPPServiceAccountName(msvc)
+
+	if err := mergo.Merge(&dest, src, mergo.WithOverride, mergo.WithAppendSlice, mergo.WithTransformers(podTemplateTransformers)); err != nil {
+		return &MergeError{Kind: "Deployment", Name: src.Name, Err: err}
+	}
+
+	// Set owner reference for the merged service
+	if err := controllerutil.SetOwnerReference(msvc, &dest, scheme); err != nil {
+		return &MergeError{Kind: "Deployment", Name: src.Name, Err: err}
+	}
+
+	// Set the merged epp deployment in the child resource
+	childResources.EPPDeployment = &dest
+
+	return nil
+}
+
+func (childResources *ChildResources) mergeEppService(msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+	if childResources == nil || childResources.EPPService == nil {
+		return nil
+	}
+	eppLabels := map[string]string{
+		"llm-d.ai/epp": EPPDeploymentName(msvc),
+	}
+	dest := *childResources.EPPService
+	src := corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EPPServiceName(msvc),
+			Namespace: msvc.Namespace,
+			Labels:    eppLabels,
+		},
+	}
+
+	src.Spec.Selector = eppLabels
+	if err := mergo.Merge(&dest, src, mergo.WithOverride); err != nil {
+		return &MergeError{Kind: "Service", Name: src.Name, Err: err}
+	}
+	if err := controllerutil.SetOwnerReference(msvc, &dest, scheme); err != nil {
+		return &MergeError{Kind: "Service", Name: src.Name, Err: err}
+	}
+
+	// Set the merged epp service in the child resource
+	childResources.EPPService = &dest
+	return nil
+}
+
+// mergeHTTPRoute uses msvc fields to update childResource HTTPRoute resource.
+func (childResources *ChildResources) mergeHTTPRoute(msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+
+	if childResources == nil {
+		return nil
+	}
+
+	if childResources.HTTPRoute == nil {
+		childResources.HTTPRoute = &gatewayv1.HTTPRoute{}
+	}
+
+	// Get dest HTTPRoute
+	dest := *childResources.HTTPRoute
+
+	group := gatewayv1.Group("inference.networking.x-k8s.io")
+	kind := gatewayv1.Kind("InferencePool")
+
+	// At this point, we are going to create the desired HTTPRoute
+	// with the parentRefs supplied by the user and
+	// with InferencePool being a backendRef (we are adding this)
+	src := &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HTTPRoute",
+			APIVersion: "gateway.networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      HTTPRouteName(msvc),
+			Namespace: msvc.Namespace,
+		},
+
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: msvc.Spec.Routing.GatewayRefs,
+			},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{
+							// InferencePool is added as a backendRef
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Group: &group,
+									Kind:  &kind,
+									Name:  gatewayv1.ObjectName(InferencePoolName(msvc)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Mergo merge src into dst
+	if err := mergo.Merge(&dest,
+		src,
+		mergo.WithOverride,
+		mergo.WithAppendSlice,
+		mergo.WithTransformers(compositeTransformer{
+			transformers: []mergo.Transformers{
+				// merge parentRef and backendRef slices
+				parentRefSliceTransformer{},
+				backendRefTransformer{},
+			},
+		}),
+	); err != nil {
+		return &MergeError{Kind: "HTTPRoute", Name: src.Name, Err: err}
+	}
+
+	// Set owner reference for the merged service
+	if err := controllerutil.SetOwnerReference(msvc, &dest, scheme); err != nil {
+		return &MergeError{Kind: "HTTPRoute", Name: src.Name, Err: err}
+	}
+
+	// Set the merged inferncepool in the child resource
+	childResources.HTTPRoute = &dest
+
+	return nil
+}
+
+// mergeInferencePool uses msvc fields to update childResource InferencePool resource.
+func (childResources *ChildResources) mergeInferencePool(ctx context.Context, msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+
+	if childResources == nil || childResources.InferencePool == nil {
+		return nil
+	}
+
+	// Get dest Service
+	dest := *childResources.InferencePool
+
+	// At this point, we are going to create a service for role
+	// srcService contains ownerRef, name for service, and selector labels
+	// srcService contains the stuff we want to override destService with
+	src := &giev1alpha2.InferencePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "InferencePool",
+			APIVersion: "inference.networking.x-k8s.io/v1alpha2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InferencePoolName(msvc),
+			Namespace: msvc.Namespace,
+		},
+		Spec: giev1alpha2.InferencePoolSpec{
+			Selector: getInferencePoolLabels(ctx, msvc),
+			EndpointPickerConfig: giev1alpha2.EndpointPickerConfig{
+				ExtensionRef: &giev1alpha2.Extension{
+					ExtensionReference: giev1alpha2.ExtensionReference{
+						Name: giev1alpha2.ObjectName(EPPServiceName(msvc)),
+					},
+				},
+			},
+		},
+	}
+
+	// Mergo merge src into dst
+	if err := mergo.Merge(&dest, src, mergo.WithOverride); err != nil {
+		return &MergeError{Kind: "InferencePool", Name: src.Name, Err: err}
+	}
+
+	// Set owner reference for the merged service
+	if err := controllerutil.SetOwnerReference(msvc, &dest, scheme); err != nil {
+		return &MergeError{Kind: "InferencePool", Name: src.Name, Err: err}
+	}
+
+	// Set the merged inferncepool in the child resource
+	childResources.InferencePool = &dest
+
+	return nil
+}
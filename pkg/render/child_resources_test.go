@@ -0,0 +1,136 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBaseConfigFromCM(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+		// expectedErrors maps each failing key to the line of its error
+		expectedErrors map[string]int
+	}{
+		{
+			name: "valid keys including rbac",
+			data: map[string]string{
+				"decodeDeployment": `spec:
+  replicas: 1
+`,
+				"eppServiceAccount": `metadata:
+  labels:
+    app: epp
+`,
+				"pdServiceAccount": `automountServiceAccountToken: false
+`,
+				"eppRoleBinding": `roleRef:
+  kind: ClusterRole
+  name: epp
+`,
+			},
+		},
+		{
+			name: "misspelled field in a deployment spec",
+			data: map[string]string{
+				"decodeDeployment": `spec:
+  replicas: 1
+  templat:
+    spec: {}
+`,
+			},
+			expectedErrors: map[string]int{"decodeDeployment": 3},
+		},
+		{
+			name: "wrong type",
+			data: map[string]string{
+				"prefillService": `spec:
+  ports:
+  - port: eighty
+`,
+			},
+			expectedErrors: map[string]int{"prefillService": 3},
+		},
+		{
+			name: "invalid yaml",
+			data: map[string]string{
+				"eppService": `spec:
+  type: ClusterIP
+ selector: {}
+`,
+			},
+			// the yaml parser reports the line of the enclosing mapping
+			expectedErrors: map[string]int{"eppService": 2},
+		},
+		{
+			name: "every bad key is reported",
+			data: map[string]string{
+				"decodeDeployment": `spec:
+  replica: 1
+`,
+				"eppRoleBinding": `roleRef:
+  kind: ClusterRole
+  nam: epp
+`,
+				"inferencePool": `spec:
+  targetPortNumber: 8000
+`,
+			},
+			expectedErrors: map[string]int{"decodeDeployment": 2, "eppRoleBinding": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc, err := BaseConfigFromCM(&corev1.ConfigMap{Data: tt.data})
+
+			if len(tt.expectedErrors) == 0 {
+				require.NoError(t, err)
+				assert.NotNil(t, bc)
+				return
+			}
+
+			assert.Nil(t, bc)
+			var decodeErrs DecodeErrors
+			require.True(t, errors.As(err, &decodeErrs), "expected DecodeErrors, got %v", err)
+
+			actual := map[string]int{}
+			for _, decodeErr := range decodeErrs {
+				actual[decodeErr.Key] = decodeErr.Line
+			}
+			assert.Equal(t, tt.expectedErrors, actual)
+		})
+	}
+}
+
+func TestBaseConfigFromCMDecodesRBAC(t *testing.T) {
+	bc, err := BaseConfigFromCM(&corev1.ConfigMap{Data: map[string]string{
+		"eppServiceAccount": "metadata:\n  labels:\n    app: epp\n",
+		"pdServiceAccount":  "automountServiceAccountToken: false\n",
+		"eppRoleBinding":    "roleRef:\n  kind: ClusterRole\n  name: epp\n",
+	}})
+	require.NoError(t, err)
+
+	require.NotNil(t, bc.EPPServiceAccount)
+	assert.Equal(t, "epp", bc.EPPServiceAccount.Labels["app"])
+	require.NotNil(t, bc.PDServiceAccount)
+	require.NotNil(t, bc.PDServiceAccount.AutomountServiceAccountToken)
+	assert.False(t, *bc.PDServiceAccount.AutomountServiceAccountToken)
+	require.NotNil(t, bc.EPPRoleBinding)
+	assert.Equal(t, "epp", bc.EPPRoleBinding.RoleRef.Name)
+}
+
+func TestUnknownBaseConfigKeys(t *testing.T) {
+	cm := &corev1.ConfigMap{Data: map[string]string{
+		"prefilDeployment": "spec: {}",
+		"decodeDeployment": "spec: {}",
+		"eppSevice":        "spec: {}",
+	}}
+
+	assert.Equal(t, []string{"eppSevice", "prefilDeployment"}, UnknownBaseConfigKeys(cm))
+	assert.Nil(t, UnknownBaseConfigKeys(nil))
+}
@@ -0,0 +1,70 @@
+/*
+Constants for utils
+*/
+
+package render
+
+const ModelStorageVolumeName = "model-storage"
+const ModelStorageRoot = "/model-cache"
+
+// hfHubCacheDir is the Hugging Face cache under HF_HOME, set to ModelStorageRoot
+const hfHubCacheDir = ModelStorageRoot + "/hub"
+
+const pathSep = "/"
+const DECODE_ROLE = "decode"
+const PREFILL_ROLE = "prefill"
+const EPP_ROLE = "epp"
+const MODEL_ARTIFACT_URI_PVC = "pvc"
+const MODEL_ARTIFACT_URI_HF = "hf"
+const MODEL_ARTIFACT_URI_OCI = "oci"
+const MODEL_ARTIFACT_URI_S3 = "s3"
+const MODEL_ARTIFACT_URI_GCS = "gs"
+const MODEL_ARTIFACT_URI_PVC_PREFIX = MODEL_ARTIFACT_URI_PVC + "://"
+const MODEL_ARTIFACT_URI_HF_PREFIX = MODEL_ARTIFACT_URI_HF + "://"
+const MODEL_ARTIFACT_URI_OCI_PREFIX = MODEL_ARTIFACT_URI_OCI + "://"
+const MODEL_ARTIFACT_URI_S3_PREFIX = MODEL_ARTIFACT_URI_S3 + "://"
+const MODEL_ARTIFACT_URI_GCS_PREFIX = MODEL_ARTIFACT_URI_GCS + "://"
+const ENV_HF_HOME = "HF_HOME"
+const ENV_HF_TOKEN = "HF_TOKEN"
+const ENV_HF_TOKEN_PATH = "HF_TOKEN_PATH"
+const HFTokenVolumeName = "hf-token"
+const HFTokenRoot = "/var/run/secrets/huggingface"
+const DEFAULT_HF_TOKEN_FILE = "token"
+const SECRETS_STORE_CSI_DRIVER = "secrets-store.csi.k8s.io"
+const DEFAULT_METRICS_PORT = "app_port"
+const DEFAULT_METRICS_PATH = "/metrics"
+const DEFAULT_HF_REVISION = "main"
+const ENV_HF_HUB_OFFLINE = "HF_HUB_OFFLINE"
+const MODEL_DOWNLOAD_CONTAINER_NAME = "model-download"
+const ENV_AWS_ACCESS_KEY_ID = "AWS_ACCESS_KEY_ID"
+const ENV_AWS_SECRET_ACCESS_KEY = "AWS_SECRET_ACCESS_KEY"
+const ENV_AWS_DEFAULT_REGION = "AWS_DEFAULT_REGION"
+const ENV_AWS_ENDPOINT_URL = "AWS_ENDPOINT_URL"
+const DEFAULT_OBJECT_STORAGE_DOWNLOAD_IMAGE = "docker.io/amazon/aws-cli:2.17.0"
+const GCS_ENDPOINT_URL = "https://storage.googleapis.com"
+const WritableCacheVolumeName = "writable-cache"
+const WritableCacheRoot = "/writable-cache"
+const ENV_XDG_CACHE_HOME = "XDG_CACHE_HOME"
+const ENV_VLLM_CACHE_ROOT = "VLLM_CACHE_ROOT"
+const ENV_TORCHINDUCTOR_CACHE_DIR = "TORCHINDUCTOR_CACHE_DIR"
+const ENV_TRITON_CACHE_DIR = "TRITON_CACHE_DIR"
+const ENV_FLASHINFER_WORKSPACE_BASE = "FLASHINFER_WORKSPACE_BASE"
+const MODEL_VERIFY_CONTAINER_NAME = "model-verify"
+const MODEL_SIGNATURE_VERIFY_CONTAINER_NAME = "model-signature-verify"
+const DEFAULT_MODEL_VERIFY_IMAGE = "docker.io/library/busybox:1.36"
+const DEFAULT_COSIGN_IMAGE = "ghcr.io/sigstore/cosign/cosign:v2.4.1"
+const ENV_COSIGN_PUBLIC_KEY = "COSIGN_PUBLIC_KEY"
+const ENV_DOCKER_CONFIG = "DOCKER_CONFIG"
+const RegistryAuthVolumeName = "registry-auth"
+const RegistryAuthRoot = "/registry-auth"
+
+type URIType string
+
+const (
+	PVC        URIType = "pvc"
+	HF         URIType = "hf"
+	OCI        URIType = "oci"
+	S3         URIType = "s3"
+	GCS        URIType = "gs"
+	UnknownURI URIType = "unknown"
+)
@@ -0,0 +1,140 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TemplateError is returned when a ModelService field or a base config key
+// cannot be rendered through the template engine
+type TemplateError struct {
+	// Source is either "modelservice" or "baseconfig"
+	Source string
+	// Key is the base config key or the container name that failed to render
+	Key string
+	Err error
+}
+
+func (e *TemplateError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("cannot render %s templates: %v", e.Source, e.Err)
+	}
+	return fmt.Sprintf("cannot render %s template %s: %v", e.Source, e.Key, e.Err)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a base config key cannot be decoded
+// into its child resource
+type DecodeError struct {
+	// Key is the base config key, e.g. prefillDeployment
+	Key string
+	// Line is the 1-based line within the value of Key where decoding
+	// failed, or 0 if it is not known
+	Line int
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("failed to decode %s (line %d): %v", e.Key, e.Line, e.Err)
+	}
+	return fmt.Sprintf("failed to decode %s: %v", e.Key, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrors collects the errors of every base config key that failed
+// to decode
+type DecodeErrors []*DecodeError
+
+func (e DecodeErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e DecodeErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// CycleError is returned when base configs reference each other as parents
+type CycleError struct {
+	// Chain lists the base configs of the cycle, starting and ending with the same one
+	Chain []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("base config parent cycle: %s", strings.Join(e.Chain, " -> "))
+}
+
+var (
+	// yamlLineRegex matches the line reported by the yaml parser
+	yamlLineRegex = regexp.MustCompile(`line (\d+)`)
+	// unknownFieldRegex matches the field reported by strict json decoding
+	unknownFieldRegex = regexp.MustCompile(`unknown field "([^"]+)"`)
+	// structFieldRegex matches the field reported for a json type mismatch
+	structFieldRegex = regexp.MustCompile(`Go struct field \S*?\.?([^.\s]+) of type`)
+)
+
+// errorLine returns the line within raw that err refers to, or 0 if
+// it cannot be determined.
+// The yaml parser reports lines directly; json decoding errors only name
+// a field, so the first line declaring that field is used
+func errorLine(raw string, err error) int {
+	msg := err.Error()
+	if m := yamlLineRegex.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+
+	var field string
+	if m := unknownFieldRegex.FindStringSubmatch(msg); m != nil {
+		field = m[1]
+	} else if m := structFieldRegex.FindStringSubmatch(msg); m != nil {
+		field = m[1]
+	} else {
+		return 0
+	}
+	// unknown nested fields are reported with their full path
+	field = field[strings.LastIndex(field, ".")+1:]
+
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimLeft(line, " \t")
+		line = strings.TrimLeft(strings.TrimPrefix(line, "-"), " \t")
+		if strings.HasPrefix(line, field+":") || strings.HasPrefix(line, `"`+field+`":`) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// MergeError is returned when the ModelService cannot be merged into
+// a child resource from the base config
+type MergeError struct {
+	// Kind is the kind of child resource, e.g. Deployment
+	Kind string
+	// Name is the name of the child resource
+	Name string
+	Err  error
+}
+
+func (e *MergeError) Error() string {
+	return fmt.Sprintf("cannot merge %s %s: %v", e.Kind, e.Name, e.Err)
+}
+
+func (e *MergeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,189 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// computedSource is reported for fields that no single layer set as is,
+// e.g. a value computed by the controller from several inputs
+const computedSource = "(computed)"
+
+// FieldSource records which layers set a field of the child resources
+type FieldSource struct {
+	// Path of the field, e.g. decodeDeployment.spec.template.spec.containers[vllm].image.
+	// List items with a name are identified by their name instead of their index
+	Path string
+	// Value is the rendered value of the field
+	Value interface{}
+	// Sources are the layers that set the field, highest precedence first.
+	// Lists of strings, such as container args, may combine several layers
+	Sources []string
+}
+
+// layerFields holds the flattened fields set by a layer
+type layerFields struct {
+	name   string
+	fields map[string]interface{}
+}
+
+// Explain renders the child resources of msvc from the base config layers,
+// root first, and reports which layer set each field.
+// The ModelService takes precedence over every base config, and each base
+// config over its parent
+func Explain(ctx context.Context, msvc *msv1alpha1.ModelService, layers []BaseConfigLayer, opts Options) ([]FieldSource, error) {
+	var baseConfigMap *corev1.ConfigMap
+	if len(layers) > 0 {
+		baseConfigMap = layers[len(layers)-1].ConfigMap
+		opts.Parents = layers[:len(layers)-1]
+	}
+
+	rendered, err := Render(ctx, msvc, baseConfigMap, opts)
+	if err != nil {
+		return nil, err
+	}
+	final, err := flattenObject(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ModelService layer holds what is rendered without any base config
+	modelServiceOnly, err := Render(ctx, msvc, nil, Options{Scheme: opts.Scheme, RBACOptions: opts.RBACOptions})
+	if err != nil {
+		return nil, err
+	}
+	modelServiceFields, err := flattenObject(modelServiceOnly)
+	if err != nil {
+		return nil, err
+	}
+	precedence := []layerFields{{name: "ModelService/" + msvc.Name, fields: modelServiceFields}}
+
+	resolvedModelService, err := ResolveTemplateVars(layers, msvc)
+	if err != nil {
+		return nil, err
+	}
+	interpolatedModelService, err := InterpolateModelService(ctx, resolvedModelService)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(layers) - 1; i >= 0; i-- {
+		baseConfig, err := LoadBaseConfig(ctx, layers[i].ConfigMap, interpolatedModelService)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := flattenObject(baseConfig)
+		if err != nil {
+			return nil, err
+		}
+		precedence = append(precedence, layerFields{name: layers[i].Name, fields: fields})
+	}
+
+	explained := make([]FieldSource, 0, len(final))
+	for path, value := range final {
+		field := FieldSource{Path: path, Value: value}
+		for _, layer := range precedence {
+			layerValue, ok := layer.fields[path]
+			if !ok {
+				continue
+			}
+			if reflect.DeepEqual(layerValue, value) {
+				field.Sources = []string{layer.name}
+				break
+			}
+			// lists of scalars may combine values from several layers
+			if overlaps(layerValue, value) {
+				field.Sources = append(field.Sources, layer.name)
+			}
+		}
+		if len(field.Sources) == 0 {
+			field.Sources = []string{computedSource}
+		}
+		explained = append(explained, field)
+	}
+
+	sort.Slice(explained, func(i, j int) bool { return explained[i].Path < explained[j].Path })
+	return explained, nil
+}
+
+// flattenObject returns the leaf fields of obj by path, using the json field names
+func flattenObject(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	flatten("", generic, fields)
+	return fields, nil
+}
+
+// flatten adds the leaf fields of value below prefix to fields
+func flatten(prefix string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, item, fields)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return
+		}
+		if !isObjectList(v) {
+			// lists of scalars are a single field
+			fields[prefix] = v
+			return
+		}
+		for i, item := range v {
+			key := fmt.Sprint(i)
+			if name, ok := item.(map[string]interface{})["name"].(string); ok {
+				key = name
+			}
+			flatten(fmt.Sprintf("%s[%s]", prefix, key), item, fields)
+		}
+	case nil:
+		// unset fields are not reported
+	default:
+		fields[prefix] = v
+	}
+}
+
+// isObjectList returns true if every item of list is an object
+func isObjectList(list []interface{}) bool {
+	for _, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps returns true if a and b are lists sharing at least one item
+func overlaps(a, b interface{}) bool {
+	aList, aOK := a.([]interface{})
+	bList, bOK := b.([]interface{})
+	if !aOK || !bOK {
+		return false
+	}
+	for _, x := range aList {
+		for _, y := range bList {
+			if reflect.DeepEqual(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
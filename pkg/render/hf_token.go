@@ -0,0 +1,118 @@
+package render
+
+import (
+	"fmt"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// hfTokenSpec returns modelArtifacts.hfToken with its defaults
+func hfTokenSpec(msvc *msv1alpha1.ModelService) msv1alpha1.HFToken {
+	var token msv1alpha1.HFToken
+	if msvc.Spec.ModelArtifacts.HFToken != nil {
+		token = *msvc.Spec.ModelArtifacts.HFToken
+	}
+	if token.SecretKey == "" {
+		token.SecretKey = ENV_HF_TOKEN
+	}
+	if token.Injection == "" {
+		token.Injection = msv1alpha1.HFTokenInjectionAll
+	}
+	return token
+}
+
+// hfTokenFromFile returns true if the token is mounted as a file, from a projected
+// service account token or the Secrets Store CSI driver, instead of read from authSecretName
+func hfTokenFromFile(msvc *msv1alpha1.ModelService) bool {
+	token := hfTokenSpec(msvc)
+	return token.ServiceAccountToken != nil || token.SecretProviderClass != nil
+}
+
+// hasHFToken returns true if a hf:// model is downloaded with a token
+func hasHFToken(msvc *msv1alpha1.ModelService) bool {
+	if !isHFURI(msvc.Spec.ModelArtifacts.URI) {
+		return false
+	}
+	return hfTokenFromFile(msvc) || msvc.Spec.ModelArtifacts.AuthSecretName != nil
+}
+
+// hfTokenInModelServer returns true if the containers mounting the model volume get the token
+func hfTokenInModelServer(msvc *msv1alpha1.ModelService) bool {
+	return hasHFToken(msvc) && hfTokenSpec(msvc).Injection != msv1alpha1.HFTokenInjectionDownloadOnly
+}
+
+// validateHFToken returns an error if the token of DownloadOnly injection would never be used
+func validateHFToken(artifacts *msv1alpha1.ModelArtifacts) error {
+	token := artifacts.HFToken
+	if token == nil || token.Injection != msv1alpha1.HFTokenInjectionDownloadOnly {
+		return nil
+	}
+	if artifacts.Download == nil && artifacts.Cache == nil {
+		return fmt.Errorf("modelArtifacts.hfToken.injection %s requires modelArtifacts.download or modelArtifacts.cache", token.Injection)
+	}
+	return nil
+}
+
+// hfTokenEnvs returns the env vars providing the token: HF_TOKEN read from
+// authSecretName, or HF_TOKEN_PATH pointing to the mounted token file
+func hfTokenEnvs(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	if !hasHFToken(msvc) {
+		return nil
+	}
+	token := hfTokenSpec(msvc)
+	switch {
+	case token.ServiceAccountToken != nil:
+		return []corev1.EnvVar{{Name: ENV_HF_TOKEN_PATH, Value: HFTokenRoot + pathSep + DEFAULT_HF_TOKEN_FILE}}
+	case token.SecretProviderClass != nil:
+		return []corev1.EnvVar{{Name: ENV_HF_TOKEN_PATH, Value: HFTokenRoot + pathSep + secretProviderClassFile(token.SecretProviderClass)}}
+	default:
+		env := secretKeyEnv(ENV_HF_TOKEN, *msvc.Spec.ModelArtifacts.AuthSecretName, false)
+		env.ValueFrom.SecretKeyRef.Key = token.SecretKey
+		return []corev1.EnvVar{env}
+	}
+}
+
+// hfTokenVolumes returns the volume holding the token file, if the token is mounted as a file
+func hfTokenVolumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	if !hasHFToken(msvc) || !hfTokenFromFile(msvc) {
+		return nil
+	}
+	token := hfTokenSpec(msvc)
+	volume := corev1.Volume{Name: HFTokenVolumeName}
+	if projection := token.ServiceAccountToken; projection != nil {
+		volume.Projected = &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+					Audience:          projection.Audience,
+					ExpirationSeconds: projection.ExpirationSeconds,
+					Path:              DEFAULT_HF_TOKEN_FILE,
+				},
+			}},
+		}
+	} else {
+		volume.CSI = &corev1.CSIVolumeSource{
+			Driver:           SECRETS_STORE_CSI_DRIVER,
+			ReadOnly:         ptr.To(true),
+			VolumeAttributes: map[string]string{"secretProviderClass": token.SecretProviderClass.Name},
+		}
+	}
+	return []corev1.Volume{volume}
+}
+
+// hfTokenMounts returns the read-only mount of the token file volume
+func hfTokenMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	if !hasHFToken(msvc) || !hfTokenFromFile(msvc) {
+		return nil
+	}
+	return []corev1.VolumeMount{{Name: HFTokenVolumeName, MountPath: HFTokenRoot, ReadOnly: true}}
+}
+
+// secretProviderClassFile returns the file of the token in the CSI volume, token by default
+func secretProviderClassFile(source *msv1alpha1.SecretProviderClassSource) string {
+	if source.File != "" {
+		return source.File
+	}
+	return DEFAULT_HF_TOKEN_FILE
+}
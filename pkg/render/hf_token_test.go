@@ -0,0 +1,155 @@
+package render
+
+import (
+	"slices"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestHFToken(t *testing.T) {
+	tokenFile := corev1.VolumeMount{Name: HFTokenVolumeName, MountPath: HFTokenRoot, ReadOnly: true}
+
+	tests := []struct {
+		name     string
+		token    *msv1alpha1.HFToken
+		download *msv1alpha1.ModelDownload
+		// env of the token in the model server and in the download container
+		serverEnv   []corev1.EnvVar
+		downloadEnv []corev1.EnvVar
+		volume      *corev1.VolumeSource
+		secretKeys  []string
+		errorMsg    string
+	}{
+		{
+			name:        "default secret key",
+			download:    &msv1alpha1.ModelDownload{Image: "python:3.12"},
+			serverEnv:   []corev1.EnvVar{secretKeyEnv(ENV_HF_TOKEN, "hf-secret", false)},
+			downloadEnv: []corev1.EnvVar{secretKeyEnv(ENV_HF_TOKEN, "hf-secret", false)},
+			secretKeys:  []string{ENV_HF_TOKEN},
+		},
+		{
+			name:     "secret key only injected into the download",
+			token:    &msv1alpha1.HFToken{SecretKey: "token", Injection: msv1alpha1.HFTokenInjectionDownloadOnly},
+			download: &msv1alpha1.ModelDownload{Image: "python:3.12"},
+			downloadEnv: []corev1.EnvVar{{
+				Name: ENV_HF_TOKEN,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "hf-secret"},
+					Key:                  "token",
+				}},
+			}},
+			secretKeys: []string{"token"},
+		},
+		{
+			name: "projected service account token",
+			token: &msv1alpha1.HFToken{ServiceAccountToken: &msv1alpha1.ServiceAccountTokenProjection{
+				Audience:          "huggingface",
+				ExpirationSeconds: ptr.To[int64](3600),
+			}},
+			serverEnv: []corev1.EnvVar{{Name: ENV_HF_TOKEN_PATH, Value: HFTokenRoot + "/token"}},
+			volume: &corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: "huggingface", ExpirationSeconds: ptr.To[int64](3600), Path: "token"},
+			}}}},
+		},
+		{
+			name: "secret provider class only injected into the download",
+			token: &msv1alpha1.HFToken{
+				SecretProviderClass: &msv1alpha1.SecretProviderClassSource{Name: "vault-hf", File: "hf-token"},
+				Injection:           msv1alpha1.HFTokenInjectionDownloadOnly,
+			},
+			download:    &msv1alpha1.ModelDownload{Image: "python:3.12"},
+			downloadEnv: []corev1.EnvVar{{Name: ENV_HF_TOKEN_PATH, Value: HFTokenRoot + "/hf-token"}},
+			volume: &corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+				Driver:           SECRETS_STORE_CSI_DRIVER,
+				ReadOnly:         ptr.To(true),
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault-hf"},
+			}},
+		},
+		{
+			name:     "download only without download",
+			token:    &msv1alpha1.HFToken{Injection: msv1alpha1.HFTokenInjectionDownloadOnly},
+			errorMsg: "modelArtifacts.hfToken.injection DownloadOnly requires modelArtifacts.download or modelArtifacts.cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{
+				URI:            "hf://facebook/opt-125m",
+				AuthSecretName: ptr.To("hf-secret"),
+				HFToken:        tt.token,
+				Download:       tt.download,
+			}
+
+			err := (&TemplateVars{}).from(msvc)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.serverEnv, tokenEnvs(getEnvsForContainer(msvc)))
+
+			volumes := getVolumeForPDDeployment(msvc)
+			if tt.volume == nil {
+				assert.Len(t, volumes, 1)
+			} else {
+				require.Len(t, volumes, 2)
+				assert.Equal(t, corev1.Volume{Name: HFTokenVolumeName, VolumeSource: *tt.volume}, volumes[1])
+			}
+			// the model server only mounts the token file if it gets the token
+			assert.Equal(t, tt.volume != nil && tt.serverEnv != nil, slices.Contains(getVolumeMountsForContainer(msvc), tokenFile))
+
+			if tt.download != nil {
+				container, err := getModelDownloadInitContainer(msvc, &msv1alpha1.PDSpec{})
+				require.NoError(t, err)
+				assert.Equal(t, tt.downloadEnv, container.Env)
+				assert.Equal(t, tt.volume != nil, len(container.VolumeMounts) == 2)
+			}
+
+			secretName, keys, ok := AuthSecretKeys(msvc)
+			assert.Equal(t, tt.secretKeys != nil, ok)
+			if ok {
+				assert.Equal(t, "hf-secret", secretName)
+				assert.Equal(t, tt.secretKeys, keys)
+			}
+		})
+	}
+}
+
+// tokenEnvs returns the env vars of envs providing the token, nil if none
+func tokenEnvs(envs []corev1.EnvVar) []corev1.EnvVar {
+	var tokens []corev1.EnvVar
+	for _, env := range envs {
+		if env.Name == ENV_HF_TOKEN || env.Name == ENV_HF_TOKEN_PATH {
+			tokens = append(tokens, env)
+		}
+	}
+	return tokens
+}
+
+func TestAuthSecretKeys(t *testing.T) {
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{URI: "s3://models/llama", AuthSecretName: ptr.To("minio")}
+	secretName, keys, ok := AuthSecretKeys(msvc)
+	require.True(t, ok)
+	assert.Equal(t, "minio", secretName)
+	// AWS_DEFAULT_REGION is optional
+	assert.Equal(t, []string{ENV_AWS_ACCESS_KEY_ID, ENV_AWS_SECRET_ACCESS_KEY}, keys)
+
+	msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{URI: "oci://quay.io/llm-d/granite:3.3", AuthSecretName: ptr.To("quay")}
+	secretName, keys, ok = AuthSecretKeys(msvc)
+	require.True(t, ok)
+	assert.Equal(t, "quay", secretName)
+	assert.Empty(t, keys)
+
+	msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{URI: "hf://facebook/opt-125m"}
+	_, _, ok = AuthSecretKeys(msvc)
+	assert.False(t, ok)
+}
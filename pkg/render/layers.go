@@ -0,0 +1,143 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"dario.cat/mergo"
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// parentKey is the base config key holding the reference to the parent base config
+const parentKey = "parent"
+
+// BaseConfigLayer is one base config of an inheritance chain
+type BaseConfigLayer struct {
+	// Name identifies the layer, e.g. ConfigMap/default/platform-base
+	Name string
+	// ConfigMap holds the base config, converted to a ConfigMap if needed
+	ConfigMap *corev1.ConfigMap
+}
+
+// BaseConfigGetter returns the base config referenced by ref as a ConfigMap.
+// namespace is used when ref does not set one
+type BaseConfigGetter func(ctx context.Context, ref *corev1.ObjectReference, namespace string) (*corev1.ConfigMap, error)
+
+// ParentRef returns the parent reference declared by the base config cm,
+// or nil if it has none
+func ParentRef(cm *corev1.ConfigMap) (*corev1.ObjectReference, error) {
+	if cm == nil {
+		return nil, nil
+	}
+	raw, ok := cm.Data[parentKey]
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	parent := &corev1.ObjectReference{}
+	if err := yaml.UnmarshalStrict([]byte(raw), parent); err != nil {
+		return nil, &DecodeError{Key: parentKey, Line: errorLine(raw, err), Err: err}
+	}
+	if parent.Name == "" {
+		return nil, &DecodeError{Key: parentKey, Err: fmt.Errorf("name is required")}
+	}
+	return parent, nil
+}
+
+// baseConfigLayerName returns the name identifying the base config referenced
+// by ref, resolving its namespace against namespace
+func baseConfigLayerName(ref *corev1.ObjectReference, namespace string) string {
+	kind := ref.Kind
+	if kind == "" {
+		kind = msv1alpha1.ConfigMapKind
+	}
+	if kind == msv1alpha1.ClusterModelServiceBaseConfigKind {
+		return kind + "/" + ref.Name
+	}
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return kind + "/" + namespace + "/" + ref.Name
+}
+
+// ResolveBaseConfigChain returns the base config referenced by ref followed by
+// its parents, root first. namespace is the namespace of the ModelService.
+// A parent defaults to the namespace of the base config declaring it
+func ResolveBaseConfigChain(ctx context.Context, ref *corev1.ObjectReference, namespace string, get BaseConfigGetter) ([]BaseConfigLayer, error) {
+	var (
+		chain []BaseConfigLayer
+		names []string
+	)
+
+	for ref != nil {
+		name := baseConfigLayerName(ref, namespace)
+		names = append(names, name)
+		if slices.Contains(names[:len(names)-1], name) {
+			return nil, &CycleError{Chain: names}
+		}
+
+		cm, err := get(ctx, ref, namespace)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, BaseConfigLayer{Name: name, ConfigMap: cm})
+
+		if ref, err = ParentRef(cm); err != nil {
+			return nil, err
+		}
+		// cluster-scoped base configs have no namespace; keep the current one
+		if cm.Namespace != "" {
+			namespace = cm.Namespace
+		}
+	}
+
+	slices.Reverse(chain)
+	return chain, nil
+}
+
+// LoadBaseConfigLayers interpolates and decodes every layer, root first, and merges each
+// layer on top of the previous one
+func LoadBaseConfigLayers(ctx context.Context, layers []BaseConfigLayer, msvc *msv1alpha1.ModelService) (_ *BaseConfig, err error) {
+	ctx, span := tracer.Start(ctx, "LoadBaseConfigLayers", trace.WithAttributes(attribute.Int("layers", len(layers))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	merged := &BaseConfig{}
+	for _, layer := range layers {
+		baseConfig, err := LoadBaseConfig(ctx, layer.ConfigMap, msvc)
+		if err != nil {
+			return nil, err
+		}
+		if err := merged.mergeLayer(baseConfig); err != nil {
+			return nil, &MergeError{Kind: "BaseConfig", Name: layer.Name, Err: err}
+		}
+	}
+	return merged, nil
+}
+
+// mergeLayer merges the base config child on top of baseConfig in place.
+// Containers and env vars are merged by name, exactly as a ModelService is
+// merged into its base config; ConfigMaps and volumes with the same name
+// are replaced by the child's
+func (baseConfig *BaseConfig) mergeLayer(child *BaseConfig) error {
+	return mergo.Merge(baseConfig, child,
+		mergo.WithOverride,
+		mergo.WithAppendSlice,
+		mergo.WithTransformers(compositeTransformer{
+			transformers: []mergo.Transformers{
+				containerSliceTransformer{},
+				envVarSliceTransformer{},
+				parentRefSliceTransformer{},
+				backendRefTransformer{},
+				configMapSliceTransformer{},
+				volumeSliceTransformer{},
+			},
+		}),
+	)
+}
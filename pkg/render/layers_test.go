@@ -0,0 +1,231 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fakeBaseConfigGetter looks up base configs by kind/namespace/name
+func fakeBaseConfigGetter(configMaps ...*corev1.ConfigMap) BaseConfigGetter {
+	return func(_ context.Context, ref *corev1.ObjectReference, namespace string) (*corev1.ConfigMap, error) {
+		name := baseConfigLayerName(ref, namespace)
+		for _, cm := range configMaps {
+			if baseConfigLayerName(&corev1.ObjectReference{Kind: msv1alpha1.ConfigMapKind, Name: cm.Name, Namespace: cm.Namespace}, "") == name {
+				return cm, nil
+			}
+		}
+		return nil, fmt.Errorf("%s not found", name)
+	}
+}
+
+func layeredConfigMap(namespace, name, parent string, data map[string]string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{},
+	}
+	for key, value := range data {
+		cm.Data[key] = value
+	}
+	if parent != "" {
+		cm.Data[parentKey] = parent
+	}
+	return cm
+}
+
+func TestResolveBaseConfigChain(t *testing.T) {
+	root := layeredConfigMap("platform", "root", "", nil)
+	middle := layeredConfigMap("platform", "middle", "name: root\n", nil)
+	leaf := layeredConfigMap("team", "leaf", "name: middle\nnamespace: platform\n", nil)
+	loopA := layeredConfigMap("team", "loop-a", "name: loop-b\n", nil)
+	loopB := layeredConfigMap("team", "loop-b", "name: loop-a\n", nil)
+	noName := layeredConfigMap("team", "no-name", "namespace: platform\n", nil)
+	get := fakeBaseConfigGetter(root, middle, leaf, loopA, loopB, noName)
+
+	tests := []struct {
+		name      string
+		ref       *corev1.ObjectReference
+		wantNames []string
+		wantErr   error
+	}{
+		{
+			name:      "no parent",
+			ref:       &corev1.ObjectReference{Name: "root", Namespace: "platform"},
+			wantNames: []string{"ConfigMap/platform/root"},
+		},
+		{
+			name: "parents are returned root first and default to the namespace of their child",
+			ref:  &corev1.ObjectReference{Name: "leaf"},
+			wantNames: []string{
+				"ConfigMap/platform/root",
+				"ConfigMap/platform/middle",
+				"ConfigMap/team/leaf",
+			},
+		},
+		{
+			name:    "cycle",
+			ref:     &corev1.ObjectReference{Name: "loop-a"},
+			wantErr: &CycleError{Chain: []string{"ConfigMap/team/loop-a", "ConfigMap/team/loop-b", "ConfigMap/team/loop-a"}},
+		},
+		{
+			name:    "parent without a name",
+			ref:     &corev1.ObjectReference{Name: "no-name"},
+			wantErr: &DecodeError{Key: parentKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := ResolveBaseConfigChain(t.Context(), tt.ref, "team", get)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				switch want := tt.wantErr.(type) {
+				case *CycleError:
+					var cycleErr *CycleError
+					require.True(t, errors.As(err, &cycleErr))
+					assert.Equal(t, want.Chain, cycleErr.Chain)
+				case *DecodeError:
+					var decodeErr *DecodeError
+					require.True(t, errors.As(err, &decodeErr))
+					assert.Equal(t, want.Key, decodeErr.Key)
+				}
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(chain))
+			for _, layer := range chain {
+				names = append(names, layer.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestLoadBaseConfigLayers(t *testing.T) {
+	root := layeredConfigMap(msvcNamespace, "root", "", map[string]string{
+		"decodeDeployment": `spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: vllm
+        image: vllm:base
+        env:
+        - name: LOG_LEVEL
+          value: info
+        - name: HF_HOME
+          value: /cache
+      volumes:
+      - name: cache
+        emptyDir: {}
+`,
+		"configMaps": `- metadata:
+    name: tuning
+  data:
+    profile: default
+`,
+	})
+	leaf := layeredConfigMap(msvcNamespace, "leaf", "name: root\n", map[string]string{
+		"decodeDeployment": `spec:
+  template:
+    spec:
+      containers:
+      - name: vllm
+        image: vllm:h100
+        env:
+        - name: LOG_LEVEL
+          value: debug
+      volumes:
+      - name: cache
+        hostPath:
+          path: /mnt/cache
+`,
+		"configMaps": `- metadata:
+    name: tuning
+  data:
+    gpu: h100
+`,
+	})
+
+	chain, err := ResolveBaseConfigChain(t.Context(), &corev1.ObjectReference{Name: "leaf"}, msvcNamespace, fakeBaseConfigGetter(root, leaf))
+	require.NoError(t, err)
+
+	bc, err := LoadBaseConfigLayers(t.Context(), chain, minimalMSVC())
+	require.NoError(t, err)
+	require.NotNil(t, bc.DecodeDeployment)
+
+	spec := bc.DecodeDeployment.Spec
+	require.NotNil(t, spec.Replicas)
+	assert.Equal(t, int32(1), *spec.Replicas, "fields not set by the child are inherited")
+
+	// containers and env vars are merged by name
+	require.Len(t, spec.Template.Spec.Containers, 1)
+	container := spec.Template.Spec.Containers[0]
+	assert.Equal(t, "vllm:h100", container.Image)
+	assert.ElementsMatch(t, []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "HF_HOME", Value: "/cache"},
+	}, container.Env)
+
+	// volumes and ConfigMaps with the same name are replaced
+	require.Len(t, spec.Template.Spec.Volumes, 1)
+	assert.Nil(t, spec.Template.Spec.Volumes[0].EmptyDir)
+	require.NotNil(t, spec.Template.Spec.Volumes[0].HostPath)
+	require.Len(t, bc.ConfigMaps, 1)
+	assert.Equal(t, map[string]string{"gpu": "h100"}, bc.ConfigMaps[0].Data)
+}
+
+func TestExplain(t *testing.T) {
+	root := layeredConfigMap(msvcNamespace, "root", "", map[string]string{
+		"decodeDeployment": `spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: vllm
+        image: vllm:base
+        args:
+        - --base-flag
+`,
+	})
+	leaf := layeredConfigMap(msvcNamespace, "leaf", "name: root\n", map[string]string{
+		"decodeDeployment": `spec:
+  template:
+    spec:
+      containers:
+      - name: vllm
+        image: vllm:h100
+`,
+	})
+	chain, err := ResolveBaseConfigChain(t.Context(), &corev1.ObjectReference{Name: "leaf"}, msvcNamespace, fakeBaseConfigGetter(root, leaf))
+	require.NoError(t, err)
+
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{
+		ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+			Containers: []msv1alpha1.ContainerSpec{{Name: "vllm", Args: []string{"--msvc-flag"}}},
+		},
+	})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, msv1alpha1.AddToScheme(scheme))
+
+	fields, err := Explain(t.Context(), msvc, chain, Options{Scheme: scheme})
+	require.NoError(t, err)
+
+	sources := map[string][]string{}
+	for _, field := range fields {
+		sources[field.Path] = field.Sources
+	}
+	container := "decodeDeployment.spec.template.spec.containers[vllm]"
+	assert.Equal(t, []string{"ConfigMap/" + msvcNamespace + "/leaf"}, sources[container+".image"])
+	assert.Equal(t, []string{"ConfigMap/" + msvcNamespace + "/root"}, sources["decodeDeployment.spec.replicas"])
+	assert.Equal(t, []string{"ModelService/" + msvc.Name, "ConfigMap/" + msvcNamespace + "/root"}, sources[container+".args"])
+}
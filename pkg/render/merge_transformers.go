@@ -1,6 +1,7 @@
-package controller
+package render
 
 import (
+	"fmt"
 	"reflect"
 	"slices"
 
@@ -53,6 +54,18 @@ func genericSliceTransformer[T any](
 	mergeFunc func(dst *T, src T) error,
 	mergeKey string) func(dst, src reflect.Value) error {
 
+	return keyedSliceTransformer(typ, mergeFunc, func(obj T) string {
+		return mergeKeyValue(obj, mergeKey)
+	})
+}
+
+// keyedSliceTransformer is genericSliceTransformer with a merge key computed by
+// mergeKeyFunc, for T objects identified by several or non-string fields
+func keyedSliceTransformer[T any](
+	typ reflect.Type,
+	mergeFunc func(dst *T, src T) error,
+	mergeKeyFunc func(obj T) string) func(dst, src reflect.Value) error {
+
 	if typ == reflect.TypeOf([]T{}) {
 		return func(dst, src reflect.Value) error {
 
@@ -69,12 +82,12 @@ func genericSliceTransformer[T any](
 			commonMergeKeys := []string{} // TODO: maybe mergeKey can be another generic type?
 
 			for _, srcObj := range srcSlice {
-				mergeKeyValue := mergeKeyValue(srcObj, mergeKey)
+				mergeKeyValue := mergeKeyFunc(srcObj)
 				srcMergeKeyMap[mergeKeyValue] = srcObj
 			}
 
 			for _, dstObj := range dstSlice {
-				mergeKeyValue := mergeKeyValue(dstObj, mergeKey)
+				mergeKeyValue := mergeKeyFunc(dstObj)
 				if _, found := srcMergeKeyMap[mergeKeyValue]; found {
 					commonMergeKeys = append(commonMergeKeys, mergeKeyValue)
 				}
@@ -83,7 +96,7 @@ func genericSliceTransformer[T any](
 			// now loop over dstSlice and see if there is a srcObj with same mergeKey value in src
 			for i, dstObj := range dstSlice {
 
-				dstMergeKeyValue := mergeKeyValue(dstObj, mergeKey)
+				dstMergeKeyValue := mergeKeyFunc(dstObj)
 
 				// Found a matching srcObj with same mergeKey value
 				if srcObj, found := srcMergeKeyMap[dstMergeKeyValue]; found {
@@ -109,7 +122,7 @@ func genericSliceTransformer[T any](
 
 			// append other src objects that weren't merged and skip the ones that are common
 			for _, srcObj := range srcSlice {
-				mergeKeyValue := mergeKeyValue(srcObj, mergeKey)
+				mergeKeyValue := mergeKeyFunc(srcObj)
 				if !slices.Contains(commonMergeKeys, mergeKeyValue) {
 					mergedSlice = append(mergedSlice, srcObj)
 				}
@@ -138,6 +151,95 @@ func (e envVarSliceTransformer) Transformer(typ reflect.Type) func(dst, src refl
 	return genericSliceTransformer(typ, mergeFunc, mergeKey)
 }
 
+// portSliceTransformer: transformer for merging two ContainerPort slices
+type portSliceTransformer struct{}
+
+// Transformer merges two []corev1.ContainerPort based on their port and protocol;
+// a src ContainerPort replaces the dst ContainerPort of the same port
+func (p portSliceTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	mergeFunc := func(dst *corev1.ContainerPort, src corev1.ContainerPort) error {
+		*dst = src
+		return nil
+	}
+	mergeKeyFunc := func(port corev1.ContainerPort) string {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		return fmt.Sprintf("%d/%s", port.ContainerPort, protocol)
+	}
+	return keyedSliceTransformer(typ, mergeFunc, mergeKeyFunc)
+}
+
+// volumeMountSliceTransformer: transformer for merging two VolumeMount slices
+type volumeMountSliceTransformer struct{}
+
+// Transformer merges two []corev1.VolumeMount based on their MountPath;
+// a src VolumeMount replaces the dst VolumeMount at the same path
+func (v volumeMountSliceTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	mergeFunc := func(dst *corev1.VolumeMount, src corev1.VolumeMount) error {
+		*dst = src
+		return nil
+	}
+	return genericSliceTransformer(typ, mergeFunc, "MountPath")
+}
+
+// probeTransformer: transformer for merging two Probes
+type probeTransformer struct{}
+
+// Transformer replaces a dst Probe with the src Probe, since merging
+// two probes could set two handlers
+func (p probeTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(&corev1.Probe{}) {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		if src.IsNil() || !dst.CanSet() {
+			return nil
+		}
+		dst.Set(reflect.ValueOf(src.Interface().(*corev1.Probe).DeepCopy()))
+		return nil
+	}
+}
+
+// tolerationSliceTransformer: transformer for merging two Toleration slices
+type tolerationSliceTransformer struct{}
+
+// Transformer merges two []corev1.Toleration based on their key, operator,
+// value and effect; a src Toleration replaces the same dst Toleration
+func (t tolerationSliceTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	mergeFunc := func(dst *corev1.Toleration, src corev1.Toleration) error {
+		*dst = src
+		return nil
+	}
+	mergeKeyFunc := func(toleration corev1.Toleration) string {
+		return fmt.Sprintf("%s/%s/%s/%s", toleration.Key, toleration.Operator, toleration.Value, toleration.Effect)
+	}
+	return keyedSliceTransformer(typ, mergeFunc, mergeKeyFunc)
+}
+
+// localObjectReferenceSliceTransformer: transformer for merging two LocalObjectReference slices
+type localObjectReferenceSliceTransformer struct{}
+
+// Transformer merges two []corev1.LocalObjectReference, such as image pull secrets,
+// based on their Name, so that a reference is not repeated
+func (l localObjectReferenceSliceTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	mergeFunc := func(dst *corev1.LocalObjectReference, src corev1.LocalObjectReference) error {
+		return nil
+	}
+	return genericSliceTransformer(typ, mergeFunc, "Name")
+}
+
+// podTemplateTransformers are the transformers for merging the pod template of
+// a base config deployment with the pod template rendered for a ModelService
+var podTemplateTransformers = compositeTransformer{
+	transformers: []mergo.Transformers{
+		containerSliceTransformer{},
+		tolerationSliceTransformer{},
+		localObjectReferenceSliceTransformer{},
+	},
+}
+
 // stringSlicePrependTransformer: transformer for merging two string slices
 type stringSlicePrependTransformer struct{}
 
@@ -203,6 +305,9 @@ func (c containerSliceTransformer) Transformer(typ reflect.Type) func(dst, src r
 			mergo.WithTransformers(compositeTransformer{
 				transformers: []mergo.Transformers{
 					envVarSliceTransformer{},
+					portSliceTransformer{},
+					volumeMountSliceTransformer{},
+					probeTransformer{},
 					stringSlicePrependTransformer{},
 				},
 			}),
@@ -276,6 +381,33 @@ func (c backendRefTransformer) Transformer(typ reflect.Type) func(dst, src refle
 	return genericSliceTransformer(typ, mergeFunc, mergeKey)
 }
 
+// configMapSliceTransformer: transformer for merging two ConfigMap slices
+type configMapSliceTransformer struct{}
+
+// Transformer merges two []corev1.ConfigMap based on their Name;
+// a src ConfigMap replaces the dst ConfigMap with the same name
+func (c configMapSliceTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	mergeFunc := func(dst *corev1.ConfigMap, src corev1.ConfigMap) error {
+		*dst = src
+		return nil
+	}
+	return genericSliceTransformer(typ, mergeFunc, "Name")
+}
+
+// volumeSliceTransformer: transformer for merging two Volume slices
+type volumeSliceTransformer struct{}
+
+// Transformer merges two []corev1.Volume based on their Name;
+// a src Volume replaces the dst Volume with the same name, since
+// merging two volume sources would set both of them
+func (v volumeSliceTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	mergeFunc := func(dst *corev1.Volume, src corev1.Volume) error {
+		*dst = src
+		return nil
+	}
+	return genericSliceTransformer(typ, mergeFunc, "Name")
+}
+
 // MergeContainerSlices merges src slice into dest in place
 func MergeContainerSlices(dest, src []corev1.Container) ([]corev1.Container, error) {
 	err := mergo.Merge(&dest, src, mergo.WithTransformers(containerSliceTransformer{}))
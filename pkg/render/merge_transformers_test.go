@@ -1,4 +1,4 @@
-package controller
+package render
 
 import (
 	"fmt"
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -198,6 +200,63 @@ func TestMergeContainerSlices(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "ports and volume mounts merged by port and mount path",
+			destSlice: []corev1.Container{
+				{
+					Name:         "c1",
+					Ports:        []corev1.ContainerPort{{Name: "http", ContainerPort: 8000}, {Name: "metrics", ContainerPort: 9090}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "shm", MountPath: "/dev/shm"}, {Name: "old-cache", MountPath: "/cache"}},
+				},
+			},
+			srcSlice: []corev1.Container{
+				{
+					Name:         "c1",
+					Ports:        []corev1.ContainerPort{{Name: "vllm", ContainerPort: 8000, Protocol: corev1.ProtocolTCP}, {Name: "nixl", ContainerPort: 5557}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+				},
+			},
+			expectedMergedSlice: []corev1.Container{
+				{
+					Name:         "c1",
+					Ports:        []corev1.ContainerPort{{Name: "vllm", ContainerPort: 8000, Protocol: corev1.ProtocolTCP}, {Name: "metrics", ContainerPort: 9090}, {Name: "nixl", ContainerPort: 5557}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "shm", MountPath: "/dev/shm"}, {Name: "cache", MountPath: "/cache"}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "probes replaced and security context merged",
+			destSlice: []corev1.Container{
+				{
+					Name: "c1",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler:  corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8000)}},
+						PeriodSeconds: 5,
+					},
+					SecurityContext: &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)},
+				},
+			},
+			srcSlice: []corev1.Container{
+				{
+					Name: "c1",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8000)}},
+					},
+					SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)},
+				},
+			},
+			expectedMergedSlice: []corev1.Container{
+				{
+					Name: "c1",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8000)}},
+					},
+					SecurityContext: &corev1.SecurityContext{RunAsNonRoot: ptr.To(true), ReadOnlyRootFilesystem: ptr.To(true)},
+				},
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,6 +285,10 @@ func TestMergeContainerSlices(t *testing.T) {
 					assertEqualSlices(t, expectedContainer.Args, actualContainer.Args)
 					assertEqualSlices(t, expectedContainer.Command, actualContainer.Command)
 					assertEqualSlices(t, expectedContainer.Env, actualContainer.Env)
+					assertEqualSlices(t, expectedContainer.Ports, actualContainer.Ports)
+					assertEqualSlices(t, expectedContainer.VolumeMounts, actualContainer.VolumeMounts)
+					assert.Equal(t, expectedContainer.ReadinessProbe, actualContainer.ReadinessProbe)
+					assert.Equal(t, expectedContainer.SecurityContext, actualContainer.SecurityContext)
 
 					// add more assertions
 					// ...
@@ -0,0 +1,150 @@
+package render
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ModelDownloadHashAnnotation records the download settings of the model download Job.
+// The pod template of a Job cannot be updated, so a Job with another hash is replaced
+const ModelDownloadHashAnnotation = "llm-d.ai/model-download-hash"
+
+// modelDownloadBackoffLimit is the number of retries of the model download Job
+const modelDownloadBackoffLimit = 3
+
+// mergeModelCache sets the PersistentVolumeClaim caching the model and the Job
+// downloading it, if msvc caches its model. It must run after the prefill and
+// decode deployments are merged, as the download image defaults to theirs
+func (childResources *ChildResources) mergeModelCache(ctx context.Context, msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+	artifacts := msvc.Spec.ModelArtifacts
+	if artifacts.Cache == nil {
+		childResources.ModelCachePVC = nil
+		childResources.ModelDownloadJob = nil
+		return nil
+	}
+
+	pvcName := ModelCachePVCName(msvc)
+	if !isDownloadableURI(artifacts.URI) {
+		return &MergeError{Kind: "PersistentVolumeClaim", Name: pvcName, Err: unsupportedURIError("modelArtifacts.cache", artifacts.URI)}
+	}
+	if artifacts.Size == nil {
+		return &MergeError{Kind: "PersistentVolumeClaim", Name: pvcName, Err: fmt.Errorf("modelArtifacts.size is required with modelArtifacts.cache")}
+	}
+	if artifacts.Download != nil && artifacts.Download.CacheClaimName != "" {
+		return &MergeError{Kind: "PersistentVolumeClaim", Name: pvcName, Err: fmt.Errorf("modelArtifacts.cache and modelArtifacts.download.cacheClaimName are mutually exclusive")}
+	}
+
+	accessMode := artifacts.Cache.AccessMode
+	if accessMode == "" {
+		accessMode = corev1.ReadWriteMany
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: msvc.Namespace,
+			Labels:    getCommonLabels(ctx, msvc),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{accessMode},
+			StorageClassName: artifacts.Cache.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: *artifacts.Size},
+			},
+		},
+	}
+	if err := controllerutil.SetOwnerReference(msvc, pvc, scheme); err != nil {
+		return &MergeError{Kind: "PersistentVolumeClaim", Name: pvcName, Err: err}
+	}
+
+	jobName := ModelDownloadJobName(msvc)
+	image := modelDownloadImage(msvc)
+	if image == "" {
+		image = childResources.modelServerImage()
+	}
+	if image == "" {
+		return &MergeError{Kind: "Job", Name: jobName, Err: fmt.Errorf("modelArtifacts.download.image is required when no container mounting the model volume sets an image")}
+	}
+	container, err := modelDownloadContainer(msvc, image)
+	if err != nil {
+		return &MergeError{Kind: "Job", Name: jobName, Err: err}
+	}
+
+	// the pods of the Job must not be selected as endpoints of the InferencePool
+	modelLabel, _ := sanitizeName(msvc.Spec.Routing.ModelName)
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: batchv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        jobName,
+			Namespace:   msvc.Namespace,
+			Labels:      getCommonLabels(ctx, msvc),
+			Annotations: map[string]string{ModelDownloadHashAnnotation: containerHash(container)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](modelDownloadBackoffLimit),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"llm-d.ai/model": modelLabel},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{*container},
+					Volumes: append([]corev1.Volume{{
+						Name: ModelStorageVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+						},
+					}}, hfTokenVolumes(msvc)...),
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetOwnerReference(msvc, job, scheme); err != nil {
+		return &MergeError{Kind: "Job", Name: jobName, Err: err}
+	}
+
+	childResources.ModelCachePVC = pvc
+	childResources.ModelDownloadJob = job
+	return nil
+}
+
+// modelServerImage returns the image of the first decode, then prefill,
+// container mounting the model volume
+func (childResources *ChildResources) modelServerImage() string {
+	for _, deployment := range []*appsv1.Deployment{childResources.DecodeDeployment, childResources.PrefillDeployment} {
+		if deployment == nil {
+			continue
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			mountsModel := slices.ContainsFunc(container.VolumeMounts, func(mount corev1.VolumeMount) bool {
+				return mount.Name == ModelStorageVolumeName
+			})
+			if mountsModel && container.Image != "" {
+				return container.Image
+			}
+		}
+	}
+	return ""
+}
+
+// containerHash returns a short hash of the image, command and env of container
+func containerHash(container *corev1.Container) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%q\n", container.Image, container.Command)
+	for _, env := range container.Env {
+		fmt.Fprintf(hash, "%s\n", env.String())
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
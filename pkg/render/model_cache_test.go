@@ -0,0 +1,158 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestModelCache(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, msv1alpha1.AddToScheme(scheme))
+
+	decode := &appsv1.Deployment{}
+	decode.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "sidecar", Image: "proxy:latest"},
+		{Name: "vllm", Image: "vllm/vllm-openai:latest", VolumeMounts: []corev1.VolumeMount{{Name: ModelStorageVolumeName, MountPath: ModelStorageRoot}}},
+	}
+
+	tests := []struct {
+		name      string
+		artifacts msv1alpha1.ModelArtifacts
+		check     func(t *testing.T, childResources *ChildResources)
+		errorMsg  string
+	}{
+		{
+			name:      "no cache",
+			artifacts: msv1alpha1.ModelArtifacts{URI: "hf://facebook/opt-125m"},
+			check: func(t *testing.T, childResources *ChildResources) {
+				assert.False(t, childResources.ShouldCreateModelCache())
+			},
+		},
+		{
+			name: "defaults",
+			artifacts: msv1alpha1.ModelArtifacts{
+				URI:   "hf://facebook/opt-125m",
+				Size:  ptr.To(resource.MustParse("10Gi")),
+				Cache: &msv1alpha1.ModelCache{},
+			},
+			check: func(t *testing.T, childResources *ChildResources) {
+				require.True(t, childResources.ShouldCreateModelCache())
+
+				pvc := childResources.ModelCachePVC
+				assert.Equal(t, ModelCachePVCName(minimalMSVC()), pvc.Name)
+				assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+				assert.Nil(t, pvc.Spec.StorageClassName)
+				assert.Equal(t, resource.MustParse("10Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+				assert.Len(t, pvc.OwnerReferences, 1)
+
+				job := childResources.ModelDownloadJob
+				assert.Equal(t, ModelDownloadJobName(minimalMSVC()), job.Name)
+				assert.Len(t, job.OwnerReferences, 1)
+				assert.NotEmpty(t, job.Annotations[ModelDownloadHashAnnotation])
+				assert.NotContains(t, job.Spec.Template.Labels, "llm-d.ai/inferenceServing")
+
+				podSpec := job.Spec.Template.Spec
+				assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+				require.Len(t, podSpec.Containers, 1)
+				assert.Equal(t, "vllm/vllm-openai:latest", podSpec.Containers[0].Image)
+				assert.Contains(t, podSpec.Containers[0].Command[2], "target='/model-cache/facebook/opt-125m/main'")
+				require.Len(t, podSpec.Volumes, 1)
+				assert.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name}, podSpec.Volumes[0].PersistentVolumeClaim)
+			},
+		},
+		{
+			name: "storage class, access mode and download settings",
+			artifacts: msv1alpha1.ModelArtifacts{
+				URI:      "hf://facebook/opt-125m",
+				Revision: "v1",
+				Size:     ptr.To(resource.MustParse("10Gi")),
+				Cache:    &msv1alpha1.ModelCache{StorageClassName: ptr.To("nfs"), AccessMode: corev1.ReadWriteOnce},
+				Download: &msv1alpha1.ModelDownload{Image: "python:3.12", AllowPatterns: []string{"*.json"}},
+			},
+			check: func(t *testing.T, childResources *ChildResources) {
+				pvc := childResources.ModelCachePVC
+				assert.Equal(t, ptr.To("nfs"), pvc.Spec.StorageClassName)
+				assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+
+				container := childResources.ModelDownloadJob.Spec.Template.Spec.Containers[0]
+				assert.Equal(t, "python:3.12", container.Image)
+				assert.Contains(t, container.Command[2], "--revision 'v1'")
+				assert.Contains(t, container.Command[2], "--include '*.json'")
+			},
+		},
+		{
+			name:      "unsupported URI",
+			artifacts: msv1alpha1.ModelArtifacts{URI: "pvc://my-pvc/model", Size: ptr.To(resource.MustParse("10Gi")), Cache: &msv1alpha1.ModelCache{}},
+			errorMsg:  "modelArtifacts.cache is not supported for pvc:// URIs",
+		},
+		{
+			name:      "no size",
+			artifacts: msv1alpha1.ModelArtifacts{URI: "hf://facebook/opt-125m", Cache: &msv1alpha1.ModelCache{}},
+			errorMsg:  "modelArtifacts.size is required",
+		},
+		{
+			name: "shared cache claim",
+			artifacts: msv1alpha1.ModelArtifacts{
+				URI:      "hf://facebook/opt-125m",
+				Size:     ptr.To(resource.MustParse("10Gi")),
+				Cache:    &msv1alpha1.ModelCache{},
+				Download: &msv1alpha1.ModelDownload{CacheClaimName: "shared"},
+			},
+			errorMsg: "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.ModelArtifacts = tt.artifacts
+			childResources := &ChildResources{DecodeDeployment: decode.DeepCopy()}
+
+			err := childResources.mergeModelCache(context.Background(), msvc, scheme)
+			if tt.errorMsg != "" {
+				var mergeErr *MergeError
+				require.ErrorAs(t, err, &mergeErr)
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, childResources)
+		})
+	}
+}
+
+func TestModelCacheVolume(t *testing.T) {
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{
+		URI:   "hf://facebook/opt-125m",
+		Size:  ptr.To(resource.MustParse("10Gi")),
+		Cache: &msv1alpha1.ModelCache{},
+	}
+	pdSpec := &msv1alpha1.PDSpec{
+		ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+			Containers: []msv1alpha1.ContainerSpec{{Name: "vllm", Image: ptr.To("vllm/vllm-openai:latest"), MountModelVolume: true}},
+		},
+	}
+
+	volumes := getVolumeForPDDeployment(msvc)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: ModelCachePVCName(msvc), ReadOnly: true}, volumes[0].PersistentVolumeClaim)
+
+	// the Job downloads the model instead of an init container
+	container, err := getModelDownloadInitContainer(msvc, pdSpec)
+	require.NoError(t, err)
+	assert.Nil(t, container)
+
+	modelPath, err := mountedModelPath(msvc)
+	require.NoError(t, err)
+	assert.Equal(t, "/model-cache/facebook/opt-125m/main", modelPath)
+}
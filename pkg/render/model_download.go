@@ -0,0 +1,199 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// modelDownloadMarker is written in the model directory once the download is verified,
+// so that later pods using the same cache skip the download
+const modelDownloadMarker = ".download-complete"
+
+// modelDownloadPartialSuffix is appended to the model directory, with the name of
+// the pod, to name the directory the model is downloaded to before it is published
+const modelDownloadPartialSuffix = ".partial-"
+
+// sha256Pattern matches a hex encoded SHA-256 digest
+var sha256Pattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// shouldDownloadModel returns true if the model is downloaded by an init container
+// or by the Job populating the model cache. Some sources, such as s3:// and gs://,
+// are always downloaded
+func shouldDownloadModel(msvc *msv1alpha1.ModelService) bool {
+	artifacts := msvc.Spec.ModelArtifacts
+	downloader, ok := lookupArtifactDownloader(artifacts.URI)
+	if !ok {
+		return false
+	}
+	return downloader.AlwaysDownload() || artifacts.Download != nil || artifacts.Cache != nil
+}
+
+// isDownloadableURI returns true if the model of uri can be downloaded by the controller
+func isDownloadableURI(uri string) bool {
+	_, ok := lookupArtifactDownloader(uri)
+	return ok
+}
+
+// modelDownloadDir returns the directory the model is downloaded to, such as
+// /model-cache/<repo-id>/<model-id>/<revision> for hf:// URIs and
+// /model-cache/<prefix> for s3:// and gs:// URIs
+func modelDownloadDir(msvc *msv1alpha1.ModelService) (string, error) {
+	downloader, ok := lookupArtifactDownloader(msvc.Spec.ModelArtifacts.URI)
+	if !ok {
+		return "", unsupportedURIError("modelArtifacts.download", msvc.Spec.ModelArtifacts.URI)
+	}
+	return downloader.DownloadDir(msvc)
+}
+
+// getModelDownloadInitContainer returns the init container downloading the model,
+// or nil if the model is not downloaded by the pods
+func getModelDownloadInitContainer(msvc *msv1alpha1.ModelService, pdSpec *msv1alpha1.PDSpec) (*corev1.Container, error) {
+	artifacts := msvc.Spec.ModelArtifacts
+	if artifacts.Download != nil && !isDownloadableURI(artifacts.URI) {
+		return nil, unsupportedURIError("modelArtifacts.download", artifacts.URI)
+	}
+	// the Job populating the model cache downloads the model instead
+	if !shouldDownloadModel(msvc) || artifacts.Cache != nil {
+		return nil, nil
+	}
+
+	image := modelDownloadImage(msvc)
+	if image == "" {
+		for _, container := range pdSpec.Containers {
+			if container.MountModelVolume && container.Image != nil {
+				image = *container.Image
+				break
+			}
+		}
+	}
+	if image == "" {
+		return nil, fmt.Errorf("modelArtifacts.download.image is required when no container mounting the model volume sets an image")
+	}
+
+	return modelDownloadContainer(msvc, image)
+}
+
+// modelDownloadImage returns the image set by download.image, or the default
+// image of the source, such as the AWS CLI for s3:// and gs:// URIs. It is
+// empty for hf:// URIs if download.image is not set
+func modelDownloadImage(msvc *msv1alpha1.ModelService) string {
+	if download := msvc.Spec.ModelArtifacts.Download; download != nil && download.Image != "" {
+		return download.Image
+	}
+	if downloader, ok := lookupArtifactDownloader(msvc.Spec.ModelArtifacts.URI); ok {
+		return downloader.DefaultDownloadImage()
+	}
+	return ""
+}
+
+// modelDownloadContainer returns the container running the download script with image
+func modelDownloadContainer(msvc *msv1alpha1.ModelService, image string) (*corev1.Container, error) {
+	downloader, ok := lookupArtifactDownloader(msvc.Spec.ModelArtifacts.URI)
+	if !ok {
+		return nil, unsupportedURIError("modelArtifacts.download", msvc.Spec.ModelArtifacts.URI)
+	}
+	script, err := modelDownloadScript(msvc, downloader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Container{
+		Name:    MODEL_DOWNLOAD_CONTAINER_NAME,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c", script},
+		Env:     downloader.DownloadEnv(msvc),
+		VolumeMounts: append([]corev1.VolumeMount{{
+			Name:      ModelStorageVolumeName,
+			MountPath: ModelStorageRoot,
+		}}, hfTokenMounts(msvc)...),
+	}, nil
+}
+
+// modelDownloadScript returns the shell script downloading the model, verifying the
+// checksums and marking the download complete. Pods sharing a cache may download
+// the model at the same time, so each one downloads it into a directory of its own,
+// and the first one to complete publishes it as a symbolic link at the model directory.
+// Creating the link fails if it exists, so a published model is never replaced
+func modelDownloadScript(msvc *msv1alpha1.ModelService, downloader ArtifactDownloader) (string, error) {
+	download := msvc.Spec.ModelArtifacts.Download
+	if download == nil {
+		download = &msv1alpha1.ModelDownload{}
+	}
+	target, err := downloader.DownloadDir(msvc)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		"set -eu",
+		"target=" + shellQuote(target),
+		fmt.Sprintf(`if [ -f "$target/%s" ]; then echo "model found in cache at $target"; exit 0; fi`, modelDownloadMarker),
+		// an interrupted download, or a link to a removed model, is replaced
+		`if [ -L "$target" ]; then [ -e "$target" ] || rm -f "$target"; else rm -rf "$target"; fi`,
+		`mkdir -p "$(dirname "$target")"`,
+		fmt.Sprintf(`dir="$target%s$(cat /proc/sys/kernel/hostname)"`, modelDownloadPartialSuffix),
+		`rm -rf "$dir"`,
+		`mkdir -p "$dir"`,
+		strings.Join(downloader.DownloadCommand(msvc, download), " "),
+	}
+
+	if len(download.Checksums) > 0 {
+		checksums, err := checksumLines(download.Checksums)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, `cd "$dir"`, sha256sumCommand(checksums))
+	}
+
+	lines = append(lines,
+		fmt.Sprintf(`touch "$dir/%s"`, modelDownloadMarker),
+		`if ln -sn "$dir" "$target" 2>/dev/null; then exit 0; fi`,
+		`echo "model published at $target by another pod"`,
+		fmt.Sprintf(`case "$dir" in "$target"%s*) rm -rf "$dir" ;; esac`, modelDownloadPartialSuffix),
+	)
+	return strings.Join(lines, "\n"), nil
+}
+
+// checksumLines returns the sha256sum lines of checksums, sorted by file,
+// or an error if a checksum is not a SHA-256 digest
+func checksumLines(checksums map[string]string) ([]string, error) {
+	files := make([]string, 0, len(checksums))
+	for file := range checksums {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+
+	lines := make([]string, 0, len(files))
+	for _, file := range files {
+		digest := strings.ToLower(checksums[file])
+		if !sha256Pattern.MatchString(digest) {
+			return nil, fmt.Errorf("checksum of %q is not a SHA-256 digest", file)
+		}
+		lines = append(lines, digest+"  "+file)
+	}
+	return lines, nil
+}
+
+// sha256sumCommand returns the command checking the checksum lines in the current directory
+func sha256sumCommand(lines []string) string {
+	return "printf '%s\\n' " + strings.Join(quoteAll(lines), " ") + " | sha256sum -c -"
+}
+
+// shellQuote quotes s as a single word for /bin/sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteAll quotes every string of values for /bin/sh
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = shellQuote(value)
+	}
+	return quoted
+}
@@ -0,0 +1,257 @@
+package render
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestModelDownload(t *testing.T) {
+	const digest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		name     string
+		uri      string
+		revision string
+		endpoint string
+		download *msv1alpha1.ModelDownload
+		image    *string
+		check    func(t *testing.T, container *corev1.Container, volumes []corev1.Volume, modelPath string)
+		errorMsg string
+	}{
+		{
+			name: "no download",
+			uri:  "hf://facebook/opt-125m",
+			check: func(t *testing.T, container *corev1.Container, volumes []corev1.Volume, modelPath string) {
+				assert.Nil(t, container)
+				assert.Equal(t, ModelStorageRoot, modelPath)
+				require.Len(t, volumes, 1)
+				assert.NotNil(t, volumes[0].EmptyDir)
+			},
+		},
+		{
+			name:     "defaults",
+			uri:      "hf://facebook/opt-125m",
+			download: &msv1alpha1.ModelDownload{},
+			image:    ptr.To("vllm/vllm-openai:latest"),
+			check: func(t *testing.T, container *corev1.Container, volumes []corev1.Volume, modelPath string) {
+				require.NotNil(t, container)
+				assert.Equal(t, MODEL_DOWNLOAD_CONTAINER_NAME, container.Name)
+				assert.Equal(t, "vllm/vllm-openai:latest", container.Image)
+				assert.Equal(t, []corev1.VolumeMount{{Name: ModelStorageVolumeName, MountPath: ModelStorageRoot}}, container.VolumeMounts)
+				assert.Equal(t, "HF_TOKEN", container.Env[0].Name)
+
+				script := container.Command[2]
+				assert.Contains(t, script, "target='/model-cache/facebook/opt-125m/main'")
+				// the model is downloaded into the Hugging Face cache, and dir is set to its snapshot
+				assert.Contains(t, script, `rmdir "$dir" && dir="$(huggingface-cli download 'facebook/opt-125m' --revision 'main' --cache-dir '/model-cache/hub')"`)
+				assert.Contains(t, script, "touch \"$dir/.download-complete\"\nif ln -sn \"$dir\" \"$target\" 2>/dev/null; then exit 0; fi")
+				assert.NotContains(t, script, "sha256sum")
+
+				assert.Equal(t, "/model-cache/facebook/opt-125m/main", modelPath)
+			},
+		},
+		{
+			name:     "revision, patterns, checksums and cache",
+			uri:      "hf://facebook/opt-125m",
+			revision: "refs/pr/1",
+			download: &msv1alpha1.ModelDownload{
+				Image:          "python:3.12",
+				AllowPatterns:  []string{"*.safetensors", "*.json"},
+				IgnorePatterns: []string{"*.bin"},
+				Checksums:      map[string]string{"model.safetensors": strings.ToUpper(digest), "config.json": digest},
+				CacheClaimName: "model-cache",
+			},
+			check: func(t *testing.T, container *corev1.Container, volumes []corev1.Volume, modelPath string) {
+				assert.Equal(t, "python:3.12", container.Image)
+
+				script := container.Command[2]
+				assert.Contains(t, script, "--revision 'refs/pr/1'")
+				assert.Contains(t, script, "--include '*.safetensors' '*.json' --exclude '*.bin')\"")
+				assert.Contains(t, script, "printf '%s\\n' '"+digest+"  config.json' '"+digest+"  model.safetensors' | sha256sum -c -")
+
+				assert.Equal(t, "/model-cache/facebook/opt-125m/refs--pr--1", modelPath)
+				require.Len(t, volumes, 1)
+				require.NotNil(t, volumes[0].PersistentVolumeClaim)
+				assert.Equal(t, "model-cache", volumes[0].PersistentVolumeClaim.ClaimName)
+				assert.False(t, volumes[0].PersistentVolumeClaim.ReadOnly)
+			},
+		},
+		{
+			name: "s3 with a MinIO endpoint",
+			uri:  "s3://models/llama/3.1-8b",
+			download: &msv1alpha1.ModelDownload{
+				AllowPatterns:  []string{"*.safetensors", "*.json"},
+				IgnorePatterns: []string{"original/*"},
+			},
+			endpoint: "http://minio.minio:9000",
+			check: func(t *testing.T, container *corev1.Container, volumes []corev1.Volume, modelPath string) {
+				require.NotNil(t, container)
+				assert.Equal(t, DEFAULT_OBJECT_STORAGE_DOWNLOAD_IMAGE, container.Image)
+
+				script := container.Command[2]
+				assert.Contains(t, script, "target='/model-cache/llama/3.1-8b'")
+				assert.Contains(t, script, `dir="$target.partial-$(cat /proc/sys/kernel/hostname)"`)
+				assert.Contains(t, script, `aws s3 sync 's3://models/llama/3.1-8b' "$dir" --only-show-errors --exclude '*' --include '*.safetensors' --include '*.json' --exclude 'original/*'`)
+
+				envs := map[string]corev1.EnvVar{}
+				for _, env := range container.Env {
+					envs[env.Name] = env
+				}
+				assert.Len(t, envs, 4)
+				assert.Equal(t, ENV_AWS_ACCESS_KEY_ID, envs[ENV_AWS_ACCESS_KEY_ID].ValueFrom.SecretKeyRef.Key)
+				assert.Equal(t, authSecretName, envs[ENV_AWS_SECRET_ACCESS_KEY].ValueFrom.SecretKeyRef.Name)
+				assert.Equal(t, ptr.To(true), envs[ENV_AWS_DEFAULT_REGION].ValueFrom.SecretKeyRef.Optional)
+				assert.Equal(t, "http://minio.minio:9000", envs[ENV_AWS_ENDPOINT_URL].Value)
+
+				assert.Equal(t, "/model-cache/llama/3.1-8b", modelPath)
+				require.Len(t, volumes, 1)
+				assert.NotNil(t, volumes[0].EmptyDir)
+			},
+		},
+		{
+			name: "gs without download settings",
+			uri:  "gs://models/opt-125m",
+			check: func(t *testing.T, container *corev1.Container, volumes []corev1.Volume, modelPath string) {
+				require.NotNil(t, container)
+				assert.Contains(t, container.Command[2], `aws s3 sync 's3://models/opt-125m' "$dir" --only-show-errors`)
+				assert.Contains(t, container.Env, corev1.EnvVar{Name: ENV_AWS_ENDPOINT_URL, Value: GCS_ENDPOINT_URL})
+				assert.Equal(t, "/model-cache/opt-125m", modelPath)
+			},
+		},
+		{
+			name:     "s3 without a prefix",
+			uri:      "s3://models",
+			errorMsg: "need s3://<bucket>/<prefix>",
+		},
+		{
+			name:     "unsupported URI",
+			uri:      "pvc://my-pvc/path/to/model",
+			download: &msv1alpha1.ModelDownload{Image: "python:3.12"},
+			errorMsg: "modelArtifacts.download is not supported for pvc:// URIs",
+		},
+		{
+			name:     "no image",
+			uri:      "hf://facebook/opt-125m",
+			download: &msv1alpha1.ModelDownload{},
+			errorMsg: "modelArtifacts.download.image is required",
+		},
+		{
+			name:     "invalid checksum",
+			uri:      "hf://facebook/opt-125m",
+			download: &msv1alpha1.ModelDownload{Image: "python:3.12", Checksums: map[string]string{"config.json": "abc"}},
+			errorMsg: `checksum of "config.json" is not a SHA-256 digest`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdSpec := &msv1alpha1.PDSpec{
+				ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+					Containers: []msv1alpha1.ContainerSpec{{Name: "vllm", Image: tt.image, MountModelVolume: true}},
+				},
+			}
+			msvc := createMSVCWithDecode(pdSpec)
+			msvc.Spec.ModelArtifacts.URI = tt.uri
+			msvc.Spec.ModelArtifacts.Revision = tt.revision
+			msvc.Spec.ModelArtifacts.Endpoint = tt.endpoint
+			msvc.Spec.ModelArtifacts.Download = tt.download
+
+			container, err := getModelDownloadInitContainer(msvc, pdSpec)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			modelPath, err := mountedModelPath(msvc)
+			require.NoError(t, err)
+			tt.check(t, container, getVolumeForPDDeployment(msvc), modelPath)
+		})
+	}
+}
+
+func TestHFDownloadEnv(t *testing.T) {
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts.URI = "hf://facebook/opt-125m"
+	msvc.Spec.ModelArtifacts.Download = &msv1alpha1.ModelDownload{}
+
+	// the model server finds the model offline by its <repo-id>/<model-id>
+	// in the cache of HF_HOME, where it is downloaded
+	envs := getEnvsForContainer(msvc)
+	assert.Contains(t, envs, corev1.EnvVar{Name: ENV_HF_HOME, Value: ModelStorageRoot})
+	assert.Contains(t, envs, corev1.EnvVar{Name: ENV_HF_HUB_OFFLINE, Value: "1"})
+	assert.Equal(t, ModelStorageRoot+"/hub", hfHubCacheDir)
+}
+
+// localDownloader downloads a fake model into a local directory with command
+type localDownloader struct {
+	nfsSource
+	dir     string
+	command string
+}
+
+func (localDownloader) AlwaysDownload() bool { return true }
+
+func (d localDownloader) DownloadDir(msvc *msv1alpha1.ModelService) (string, error) {
+	return d.dir, nil
+}
+
+func (d localDownloader) DownloadCommand(msvc *msv1alpha1.ModelService, download *msv1alpha1.ModelDownload) []string {
+	return []string{d.command}
+}
+
+func (localDownloader) DownloadEnv(msvc *msv1alpha1.ModelService) []corev1.EnvVar { return nil }
+
+func (localDownloader) DefaultDownloadImage() string { return "" }
+
+func TestModelDownloadScriptPublishesOnce(t *testing.T) {
+	if _, err := os.Stat("/proc/sys/kernel/hostname"); err != nil {
+		t.Skip("the download script reads the pod name from /proc")
+	}
+	root := t.TempDir()
+	target := filepath.Join(root, "org", "model")
+	run := func(command string) string {
+		script, err := modelDownloadScript(minimalMSVC(), localDownloader{dir: target, command: command})
+		require.NoError(t, err)
+		out, err := exec.Command("/bin/sh", "-c", script).CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+
+	// the first download is published as a link to the directory it was downloaded to
+	run(`echo first > "$dir/weights"`)
+	weights, err := os.ReadFile(filepath.Join(target, "weights"))
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(weights))
+	link, err := os.Readlink(target)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(link, target+modelDownloadPartialSuffix))
+
+	// later pods skip the download
+	assert.Contains(t, run(`echo second > "$dir/weights"`), "model found in cache")
+
+	// a pod that completes a download after another one published the model keeps it,
+	// and removes its own copy
+	require.NoError(t, os.Remove(target))
+	other := filepath.Join(root, "other")
+	require.NoError(t, os.MkdirAll(other, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(other, modelDownloadMarker), nil, 0o644))
+	out := run(`echo third > "$dir/weights"; ln -s '` + other + `' "$target"`)
+	assert.Contains(t, out, "published at "+target+" by another pod")
+	link, err = os.Readlink(target)
+	require.NoError(t, err)
+	assert.Equal(t, other, link)
+	// every run has the host name of the test, so the third one replaced the first copy
+	partials, err := filepath.Glob(target + modelDownloadPartialSuffix + "*")
+	require.NoError(t, err)
+	assert.Empty(t, partials)
+}
@@ -0,0 +1,167 @@
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ModelImageReference returns the image reference of an oci:// URI, as written
+// in the URI, and false for other URIs
+func ModelImageReference(msvc *msv1alpha1.ModelService) (string, bool) {
+	reference, _, err := parseOCIURI(&msvc.Spec.ModelArtifacts)
+	if err != nil {
+		return "", false
+	}
+	return reference, true
+}
+
+// ManifestDigest returns the sha256:<hex> digest of a verification manifest,
+// computed over its sha256sum lines sorted by file
+func ManifestDigest(manifest map[string]string) (string, error) {
+	lines, err := checksumLines(manifest)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n") + "\n"))
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// pinnedImageReference returns reference pinned to the approved digest of
+// modelArtifacts.verification, replacing its tag or digest
+func pinnedImageReference(msvc *msv1alpha1.ModelService, reference string) string {
+	verification := msvc.Spec.ModelArtifacts.Verification
+	if verification == nil || verification.Digest == "" {
+		return reference
+	}
+	repository, _, _ := strings.Cut(reference, "@")
+	// a tag follows the last colon after the last slash; earlier colons are registry ports
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, pathSep) {
+		repository = repository[:i]
+	}
+	return repository + "@" + verification.Digest
+}
+
+// getModelVerificationInitContainers returns the init containers checking the
+// signature of an oci:// image and the manifest of the model, in that order
+func getModelVerificationInitContainers(msvc *msv1alpha1.ModelService) ([]corev1.Container, error) {
+	artifacts := msvc.Spec.ModelArtifacts
+	verification := artifacts.Verification
+	if verification == nil {
+		return nil, nil
+	}
+
+	reference, isOCI := ModelImageReference(msvc)
+	if verification.Digest != "" && !isOCI {
+		return nil, unsupportedURIError("modelArtifacts.verification.digest", artifacts.URI)
+	}
+	if verification.Signature != nil && !isOCI {
+		return nil, unsupportedURIError("modelArtifacts.verification.signature", artifacts.URI)
+	}
+
+	var containers []corev1.Container
+	if verification.Signature != nil {
+		containers = append(containers, signatureVerifyContainer(msvc, pinnedImageReference(msvc, reference)))
+	}
+
+	if len(verification.Manifest) > 0 {
+		// a model downloaded by the model server is not there yet when the init containers run
+		if isDownloadableURI(artifacts.URI) && !shouldDownloadModel(msvc) {
+			return nil, fmt.Errorf("modelArtifacts.verification.manifest requires modelArtifacts.download or modelArtifacts.cache for %s:// URIs", UriType(artifacts.URI))
+		}
+		container, err := manifestVerifyContainer(msvc)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, *container)
+	}
+	return containers, nil
+}
+
+// manifestVerifyContainer returns the container checking the manifest in the mounted model path
+func manifestVerifyContainer(msvc *msv1alpha1.ModelService) (*corev1.Container, error) {
+	verification := msvc.Spec.ModelArtifacts.Verification
+	checksums, err := checksumLines(verification.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("modelArtifacts.verification.manifest: %w", err)
+	}
+	modelPath, err := mountedModelPath(msvc)
+	if err != nil {
+		return nil, err
+	}
+
+	image := verification.Image
+	if image == "" {
+		image = DEFAULT_MODEL_VERIFY_IMAGE
+	}
+	script := strings.Join([]string{
+		"set -eu",
+		"cd " + shellQuote(modelPath),
+		sha256sumCommand(checksums),
+	}, "\n")
+
+	return &corev1.Container{
+		Name:         MODEL_VERIFY_CONTAINER_NAME,
+		Image:        image,
+		Command:      []string{"/bin/sh", "-c", script},
+		VolumeMounts: getVolumeMountsForContainer(msvc),
+	}, nil
+}
+
+// signatureVerifyContainer returns the container verifying the cosign signature of reference.
+// The registry is authenticated with the .dockerconfigjson of authSecretName, if set
+func signatureVerifyContainer(msvc *msv1alpha1.ModelService, reference string) corev1.Container {
+	signature := msvc.Spec.ModelArtifacts.Verification.Signature
+
+	image := signature.Image
+	if image == "" {
+		image = DEFAULT_COSIGN_IMAGE
+	}
+
+	args := []string{"verify"}
+	var envs []corev1.EnvVar
+	if signature.PublicKey != "" {
+		args = append(args, "--key", "env://"+ENV_COSIGN_PUBLIC_KEY)
+		envs = append(envs, corev1.EnvVar{Name: ENV_COSIGN_PUBLIC_KEY, Value: signature.PublicKey})
+	} else {
+		args = append(args,
+			"--certificate-identity", signature.CertificateIdentity,
+			"--certificate-oidc-issuer", signature.CertificateOIDCIssuer,
+		)
+	}
+	args = append(args, reference)
+
+	container := corev1.Container{
+		Name:  MODEL_SIGNATURE_VERIFY_CONTAINER_NAME,
+		Image: image,
+		Args:  args,
+		Env:   envs,
+	}
+	if msvc.Spec.ModelArtifacts.AuthSecretName != nil {
+		container.Env = append(container.Env, corev1.EnvVar{Name: ENV_DOCKER_CONFIG, Value: RegistryAuthRoot})
+		container.VolumeMounts = []corev1.VolumeMount{{Name: RegistryAuthVolumeName, MountPath: RegistryAuthRoot, ReadOnly: true}}
+	}
+	return container
+}
+
+// modelVerificationVolumes returns the volume holding the registry credentials
+// of the signature verification, if authSecretName is set
+func modelVerificationVolumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	artifacts := msvc.Spec.ModelArtifacts
+	if artifacts.Verification == nil || artifacts.Verification.Signature == nil || artifacts.AuthSecretName == nil {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: RegistryAuthVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: *artifacts.AuthSecretName,
+				Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+			},
+		},
+	}}
+}
@@ -0,0 +1,172 @@
+package render
+
+import (
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestModelVerification(t *testing.T) {
+	const digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	const checksum = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	tests := []struct {
+		name         string
+		uri          string
+		auth         *string
+		download     *msv1alpha1.ModelDownload
+		verification *msv1alpha1.ModelVerification
+		check        func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume)
+		errorMsg     string
+	}{
+		{
+			name: "no verification",
+			uri:  "oci://quay.io/llm-d/granite:3.3",
+			check: func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume) {
+				assert.Empty(t, containers)
+				assert.Equal(t, "quay.io/llm-d/granite:3.3", volumes[0].Image.Reference)
+			},
+		},
+		{
+			name:         "digest pins the image volume",
+			uri:          "oci://registry.local:5000/llm-d/granite:3.3::models/granite",
+			verification: &msv1alpha1.ModelVerification{Digest: digest},
+			check: func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume) {
+				assert.Empty(t, containers)
+				assert.Equal(t, "registry.local:5000/llm-d/granite@"+digest, volumes[0].Image.Reference)
+			},
+		},
+		{
+			name: "signature with public key",
+			uri:  "oci://quay.io/llm-d/granite:3.3",
+			auth: ptr.To("registry-creds"),
+			verification: &msv1alpha1.ModelVerification{
+				Digest:    digest,
+				Signature: &msv1alpha1.SignatureVerification{PublicKey: "-----BEGIN PUBLIC KEY-----"},
+			},
+			check: func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume) {
+				require.Len(t, containers, 1)
+				assert.Equal(t, MODEL_SIGNATURE_VERIFY_CONTAINER_NAME, containers[0].Name)
+				assert.Equal(t, DEFAULT_COSIGN_IMAGE, containers[0].Image)
+				assert.Equal(t, []string{"verify", "--key", "env://COSIGN_PUBLIC_KEY", "quay.io/llm-d/granite@" + digest}, containers[0].Args)
+				assert.Contains(t, containers[0].Env, corev1.EnvVar{Name: ENV_DOCKER_CONFIG, Value: RegistryAuthRoot})
+
+				require.Len(t, volumes, 2)
+				assert.Equal(t, RegistryAuthVolumeName, volumes[1].Name)
+				assert.Equal(t, "registry-creds", volumes[1].Secret.SecretName)
+			},
+		},
+		{
+			name: "keyless signature",
+			uri:  "oci://quay.io/llm-d/granite@" + digest,
+			verification: &msv1alpha1.ModelVerification{
+				Digest: digest,
+				Signature: &msv1alpha1.SignatureVerification{
+					CertificateIdentity:   "https://github.com/llm-d/models/.github/workflows/release.yaml@refs/heads/main",
+					CertificateOIDCIssuer: "https://token.actions.githubusercontent.com",
+					Image:                 "cosign:dev",
+				},
+			},
+			check: func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume) {
+				require.Len(t, containers, 1)
+				assert.Equal(t, "cosign:dev", containers[0].Image)
+				assert.Equal(t, []string{
+					"verify",
+					"--certificate-identity", "https://github.com/llm-d/models/.github/workflows/release.yaml@refs/heads/main",
+					"--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+					"quay.io/llm-d/granite@" + digest,
+				}, containers[0].Args)
+				assert.Empty(t, containers[0].Env)
+				assert.Len(t, volumes, 1)
+			},
+		},
+		{
+			name:         "manifest of a pvc model",
+			uri:          "pvc://models/granite/3.3",
+			verification: &msv1alpha1.ModelVerification{Manifest: map[string]string{"model.safetensors": checksum, "config.json": checksum}},
+			check: func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume) {
+				require.Len(t, containers, 1)
+				assert.Equal(t, MODEL_VERIFY_CONTAINER_NAME, containers[0].Name)
+				assert.Equal(t, DEFAULT_MODEL_VERIFY_IMAGE, containers[0].Image)
+				assert.Equal(t, "set -eu\ncd '/model-cache/granite/3.3'\nprintf '%s\\n' '"+checksum+"  config.json' '"+checksum+"  model.safetensors' | sha256sum -c -", containers[0].Command[2])
+				assert.Equal(t, getVolumeMountsForContainer(&msv1alpha1.ModelService{Spec: msv1alpha1.ModelServiceSpec{ModelArtifacts: msv1alpha1.ModelArtifacts{URI: "pvc://models/granite/3.3"}}}), containers[0].VolumeMounts)
+			},
+		},
+		{
+			name:         "manifest of a downloaded model",
+			uri:          "hf://facebook/opt-125m",
+			download:     &msv1alpha1.ModelDownload{Image: "python:3.12"},
+			verification: &msv1alpha1.ModelVerification{Manifest: map[string]string{"config.json": checksum}, Image: "alpine:3.20"},
+			check: func(t *testing.T, containers []corev1.Container, volumes []corev1.Volume) {
+				require.Len(t, containers, 1)
+				assert.Equal(t, "alpine:3.20", containers[0].Image)
+				assert.Contains(t, containers[0].Command[2], "cd '/model-cache/facebook/opt-125m/main'")
+			},
+		},
+		{
+			name:         "manifest of a model downloaded by the model server",
+			uri:          "hf://facebook/opt-125m",
+			verification: &msv1alpha1.ModelVerification{Manifest: map[string]string{"config.json": checksum}},
+			errorMsg:     "modelArtifacts.verification.manifest requires modelArtifacts.download or modelArtifacts.cache for hf:// URIs",
+		},
+		{
+			name:         "invalid manifest",
+			uri:          "pvc://models/granite",
+			verification: &msv1alpha1.ModelVerification{Manifest: map[string]string{"config.json": "md5"}},
+			errorMsg:     `modelArtifacts.verification.manifest: checksum of "config.json" is not a SHA-256 digest`,
+		},
+		{
+			name:         "digest of a pvc model",
+			uri:          "pvc://models/granite",
+			verification: &msv1alpha1.ModelVerification{Digest: digest},
+			errorMsg:     "modelArtifacts.verification.digest is not supported for pvc:// URIs",
+		},
+		{
+			name:         "signature of a hf model",
+			uri:          "hf://facebook/opt-125m",
+			verification: &msv1alpha1.ModelVerification{Signature: &msv1alpha1.SignatureVerification{PublicKey: "key"}},
+			errorMsg:     "modelArtifacts.verification.signature is not supported for hf:// URIs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{
+				URI:            tt.uri,
+				AuthSecretName: tt.auth,
+				Download:       tt.download,
+				Verification:   tt.verification,
+			}
+
+			containers, err := getModelVerificationInitContainers(msvc)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, containers, getVolumeForPDDeployment(msvc))
+		})
+	}
+}
+
+func TestManifestDigest(t *testing.T) {
+	const checksum = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	digest, err := ManifestDigest(map[string]string{"model.safetensors": checksum, "config.json": checksum})
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha256:[a-f0-9]{64}$`, digest)
+
+	// the digest does not depend on the case of the checksums
+	upper, err := ManifestDigest(map[string]string{"model.safetensors": checksum, "config.json": "2C26B46B68FFC68FF99B453C1D30413413422D706483BFA0F98A5E886266E7AE"})
+	require.NoError(t, err)
+	assert.Equal(t, digest, upper)
+
+	other, err := ManifestDigest(map[string]string{"model.safetensors": checksum})
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, other)
+}
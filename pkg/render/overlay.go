@@ -0,0 +1,259 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+// overlaysKey is the base config key holding structured patches by key.
+// It is not interpolated with Go templates
+const overlaysKey = "overlays"
+
+// celExpressionPrefix and celExpressionSuffix delimit a CEL expression in an
+// overlay string value; celEscape at the start of a string stands for a literal "${"
+const (
+	celExpressionPrefix = "${"
+	celExpressionSuffix = "}"
+	celEscape           = "$${"
+)
+
+// Overlays returns the overlays declared by the base config cm by key,
+// or nil if it declares none
+func Overlays(cm *corev1.ConfigMap) (map[string]msv1alpha1.BaseConfigOverlay, error) {
+	if cm == nil {
+		return nil, nil
+	}
+	raw, ok := cm.Data[overlaysKey]
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	overlays := map[string]msv1alpha1.BaseConfigOverlay{}
+	if err := yaml.UnmarshalStrict([]byte(raw), &overlays); err != nil {
+		return nil, &DecodeError{Key: overlaysKey, Line: errorLine(raw, err), Err: err}
+	}
+	for key, overlay := range overlays {
+		if _, ok := overlayTarget(key); !ok {
+			return nil, &DecodeError{Key: overlaysKey, Err: fmt.Errorf("unknown base config key %q", key)}
+		}
+		if overlay.StrategicMerge != nil && key == "configMaps" {
+			return nil, &DecodeError{Key: overlaysKey, Err: fmt.Errorf("configMaps is a list and only supports jsonPatch")}
+		}
+	}
+	return overlays, nil
+}
+
+// overlayTarget returns the type decoded from the base config key,
+// as declared by the json tags of BaseConfig
+func overlayTarget(key string) (reflect.Type, bool) {
+	t := reflect.TypeOf(BaseConfig{})
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == key {
+			fieldType := t.Field(i).Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			return fieldType, true
+		}
+	}
+	return nil, false
+}
+
+// applyOverlays applies the overlays of cm, an interpolated base config, in place.
+// The overlays key is removed once applied
+func applyOverlays(cm *corev1.ConfigMap, msvc *msv1alpha1.ModelService, values *TemplateVars) error {
+	overlays, err := Overlays(cm)
+	if err != nil || overlays == nil {
+		return err
+	}
+
+	activation, err := celActivation(msvc, values)
+	if err != nil {
+		return &TemplateError{Source: "overlay", Err: err}
+	}
+	env, err := cel.NewEnv(
+		cel.Variable("msvc", cel.DynType),
+		cel.Variable("vars", cel.DynType),
+		cel.Variable("ports", cel.MapType(cel.StringType, cel.IntType)),
+		ext.Strings(),
+	)
+	if err != nil {
+		return &TemplateError{Source: "overlay", Err: err}
+	}
+
+	for key, overlay := range overlays {
+		patched, err := applyOverlay(key, cm.Data[key], &overlay, env, activation)
+		if err != nil {
+			return &TemplateError{Source: "overlay", Key: key, Err: err}
+		}
+		cm.Data[key] = patched
+	}
+	delete(cm.Data, overlaysKey)
+
+	return nil
+}
+
+// applyOverlay returns the yaml document raw of the base config key, with
+// overlay applied. An empty document is patched as an empty object or list
+func applyOverlay(key string, raw string, overlay *msv1alpha1.BaseConfigOverlay, env *cel.Env, activation map[string]interface{}) (string, error) {
+	target, _ := overlayTarget(key)
+
+	doc := []byte("{}")
+	if target.Kind() == reflect.Slice {
+		doc = []byte("[]")
+	}
+	if strings.TrimSpace(raw) != "" {
+		var err error
+		if doc, err = yaml.YAMLToJSON([]byte(raw)); err != nil {
+			return "", err
+		}
+	}
+
+	if overlay.StrategicMerge != nil {
+		patch, err := evaluateOverlay(overlay.StrategicMerge.Raw, env, activation)
+		if err != nil {
+			return "", err
+		}
+		if doc, err = strategicpatch.StrategicMergePatch(doc, patch, reflect.New(target).Elem().Interface()); err != nil {
+			return "", fmt.Errorf("strategic merge patch: %w", err)
+		}
+	}
+
+	if len(overlay.JSONPatch) > 0 {
+		ops, err := json.Marshal(overlay.JSONPatch)
+		if err != nil {
+			return "", err
+		}
+		if ops, err = evaluateOverlay(ops, env, activation); err != nil {
+			return "", err
+		}
+		patch, err := jsonpatch.DecodePatch(ops)
+		if err != nil {
+			return "", fmt.Errorf("json patch: %w", err)
+		}
+		if doc, err = patch.Apply(doc); err != nil {
+			return "", fmt.Errorf("json patch: %w", err)
+		}
+	}
+
+	patched, err := yaml.JSONToYAML(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(patched), nil
+}
+
+// celActivation returns the CEL variables: msvc is the ModelService, vars the
+// template variables by json name and ports the routing ports by name
+func celActivation(msvc *msv1alpha1.ModelService, values *TemplateVars) (map[string]interface{}, error) {
+	toGeneric := func(obj interface{}) (interface{}, error) {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		var generic interface{}
+		return generic, json.Unmarshal(data, &generic)
+	}
+
+	msvcValue, err := toGeneric(msvc)
+	if err != nil {
+		return nil, err
+	}
+	varsValue, err := toGeneric(values)
+	if err != nil {
+		return nil, err
+	}
+	ports := map[string]int64{}
+	for _, port := range msvc.Spec.Routing.Ports {
+		ports[port.Name] = int64(port.Port)
+	}
+
+	return map[string]interface{}{"msvc": msvcValue, "vars": varsValue, "ports": ports}, nil
+}
+
+// evaluateOverlay returns the json document data with every CEL expression
+// replaced by its result
+func evaluateOverlay(data []byte, env *cel.Env, activation map[string]interface{}) ([]byte, error) {
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	evaluated, err := evaluateExpressions(generic, env, activation)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(evaluated)
+}
+
+// evaluateExpressions replaces every string of value that is a CEL expression
+// by its result
+func evaluateExpressions(value interface{}, env *cel.Env, activation map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			evaluated, err := evaluateExpressions(item, env, activation)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = evaluated
+		}
+		return v, nil
+
+	case []interface{}:
+		for i, item := range v {
+			evaluated, err := evaluateExpressions(item, env, activation)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = evaluated
+		}
+		return v, nil
+
+	case string:
+		if strings.HasPrefix(v, celEscape) {
+			return v[1:], nil
+		}
+		if !strings.HasPrefix(v, celExpressionPrefix) || !strings.HasSuffix(v, celExpressionSuffix) {
+			return v, nil
+		}
+		expression := strings.TrimSpace(v[len(celExpressionPrefix) : len(v)-len(celExpressionSuffix)])
+		return evaluateExpression(expression, env, activation)
+
+	default:
+		return v, nil
+	}
+}
+
+// evaluateExpression returns the result of the CEL expression as a json value
+func evaluateExpression(expression string, env *cel.Env, activation map[string]interface{}) (interface{}, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", expression, issues.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", expression, err)
+	}
+	out, _, err := program.Eval(activation)
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate %q: %w", expression, err)
+	}
+
+	native, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert the result of %q to json: %w", expression, err)
+	}
+	return native.(*structpb.Value).AsInterface(), nil
+}
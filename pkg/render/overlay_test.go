@@ -0,0 +1,139 @@
+package render
+
+import (
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestOverlays(t *testing.T) {
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{})
+	msvc.Spec.Routing.Ports = []msv1alpha1.Port{{Name: "app_port", Port: 8000}}
+
+	tests := []struct {
+		name     string
+		data     map[string]string
+		check    func(t *testing.T, bc *BaseConfig)
+		errorMsg string
+	}{
+		{
+			name: "strategic merge patch on top of a Go template",
+			data: map[string]string{
+				"decodeDeployment": `spec:
+  template:
+    spec:
+      containers:
+      - name: vllm
+        image: vllm:latest
+        args: ["{{ .ModelPath }}"]
+      - name: sidecar
+        image: proxy:latest
+`,
+				overlaysKey: `decodeDeployment:
+  strategicMerge:
+    spec:
+      replicas: 2
+      template:
+        spec:
+          containers:
+          - name: vllm
+            ports:
+            - containerPort: "${ ports.app_port }"
+            env:
+            - name: MODEL
+              value: "${ msvc.spec.routing.modelName }"
+`,
+			},
+			check: func(t *testing.T, bc *BaseConfig) {
+				spec := bc.DecodeDeployment.Spec
+				assert.Equal(t, int32(2), *spec.Replicas)
+				containers := spec.Template.Spec.Containers
+				require.Len(t, containers, 2, "containers are merged by name")
+				assert.Equal(t, "vllm:latest", containers[0].Image)
+				assert.Equal(t, []string{modelPath}, containers[0].Args)
+				assert.Equal(t, int32(8000), containers[0].Ports[0].ContainerPort)
+				assert.Equal(t, []corev1.EnvVar{{Name: "MODEL", Value: modelName}}, containers[0].Env)
+			},
+		},
+		{
+			name: "json patch without a template",
+			data: map[string]string{
+				overlaysKey: `eppService:
+  jsonPatch:
+  - op: add
+    path: /spec
+    value:
+      type: ClusterIP
+      ports:
+      - name: grpc
+        port: 9002
+  - op: add
+    path: /metadata
+    value:
+      labels:
+        kv-connector: '{"kv_connector":"NixlConnector"}'
+        literal: "$${ not an expression }"
+        model: '${ vars.modelPath.replace("/", "-") }'
+`,
+			},
+			check: func(t *testing.T, bc *BaseConfig) {
+				require.NotNil(t, bc.EPPService)
+				assert.Equal(t, corev1.ServiceTypeClusterIP, bc.EPPService.Spec.Type)
+				assert.Equal(t, int32(9002), bc.EPPService.Spec.Ports[0].Port)
+				assert.Equal(t, map[string]string{
+					"kv-connector": `{"kv_connector":"NixlConnector"}`,
+					"literal":      "${ not an expression }",
+					"model":        "path-to-modelName",
+				}, bc.EPPService.Labels)
+			},
+		},
+		{
+			name: "json patch on configMaps",
+			data: map[string]string{
+				"configMaps": "- metadata:\n    name: first\n",
+				overlaysKey: `configMaps:
+  jsonPatch:
+  - op: add
+    path: /-
+    value:
+      metadata:
+        name: second
+`,
+			},
+			check: func(t *testing.T, bc *BaseConfig) {
+				require.Len(t, bc.ConfigMaps, 2)
+				assert.Equal(t, "second", bc.ConfigMaps[1].Name)
+			},
+		},
+		{
+			name:     "strategic merge on configMaps",
+			data:     map[string]string{overlaysKey: "configMaps:\n  strategicMerge: {}\n"},
+			errorMsg: "only supports jsonPatch",
+		},
+		{
+			name:     "unknown key",
+			data:     map[string]string{overlaysKey: "prefilDeployment:\n  strategicMerge: {}\n"},
+			errorMsg: `unknown base config key "prefilDeployment"`,
+		},
+		{
+			name:     "invalid expression",
+			data:     map[string]string{overlaysKey: "decodeService:\n  strategicMerge:\n    spec:\n      type: '${ msvc.spec.( }'\n"},
+			errorMsg: "cannot render overlay template decodeService",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc, err := LoadBaseConfig(t.Context(), &corev1.ConfigMap{Data: tt.data}, msvc)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, bc)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package render
+
+import (
+	"context"
+	"slices"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelServiceLabel is set on the prefill and decode pods of a ModelService with a
+// placement, so that its affinities only select the pods of the ModelService. It is
+// not part of the deployment selectors, which are immutable
+const ModelServiceLabel = "llm-d.ai/modelservice"
+
+// defaultPlacementWeight is the weight of the preferred affinities of a placement
+const defaultPlacementWeight = 100
+
+// rolePlacement returns the placement of role, nil if not set
+func rolePlacement(msvc *msv1alpha1.ModelService, role string) *msv1alpha1.RolePlacement {
+	placement := msvc.Spec.Placement
+	if placement == nil {
+		return nil
+	}
+	if role == PREFILL_ROLE {
+		return placement.Prefill
+	}
+	return placement.Decode
+}
+
+// placementPodLabels returns the pod labels of role, with ModelServiceLabel if msvc has a placement
+func placementPodLabels(ctx context.Context, msvc *msv1alpha1.ModelService, role string) map[string]string {
+	labels := getPodLabels(ctx, msvc, role)
+	if msvc.Spec.Placement != nil {
+		labels[ModelServiceLabel] = msvc.Name
+	}
+	return labels
+}
+
+// pdAffinity returns the affinity of the pods of role: the node affinity of
+// the accelerator types, and the pod affinities of the placement
+func pdAffinity(ctx context.Context, msvc *msv1alpha1.ModelService, pdSpec *msv1alpha1.PDSpec, role string) (*corev1.Affinity, error) {
+	nodeAffinity, err := AcceleratorTypesToNodeAffinity(pdSpec.AcceleratorTypes)
+	if err != nil {
+		return nil, err
+	}
+	affinity := &corev1.Affinity{NodeAffinity: nodeAffinity}
+
+	if pair := pairAffinityTerm(ctx, msvc, role); pair != nil {
+		affinity.PodAffinity = &corev1.PodAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{*pair},
+		}
+	}
+
+	if antiAffinity := replicaAntiAffinity(ctx, msvc, role); antiAffinity != nil {
+		affinity.PodAntiAffinity = antiAffinity
+	}
+
+	if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		return nil, nil
+	}
+	return affinity, nil
+}
+
+// pairAffinityTerm returns the preferred affinity of the pods of role to the pods of
+// the other role of the ModelService, or nil if pairAffinity is not set
+func pairAffinityTerm(ctx context.Context, msvc *msv1alpha1.ModelService, role string) *corev1.WeightedPodAffinityTerm {
+	if msvc.Spec.Placement == nil || msvc.Spec.Placement.PairAffinity == nil {
+		return nil
+	}
+	pair := msvc.Spec.Placement.PairAffinity
+
+	peer := DECODE_ROLE
+	if role == DECODE_ROLE {
+		peer = PREFILL_ROLE
+	}
+	weight := pair.Weight
+	if weight == 0 {
+		weight = defaultPlacementWeight
+	}
+	return &corev1.WeightedPodAffinityTerm{
+		Weight: weight,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: placementPodLabels(ctx, msvc, peer)},
+			TopologyKey:   pair.TopologyKey,
+		},
+	}
+}
+
+// replicaAntiAffinity returns the anti-affinity between the pods of role, or nil if not set
+func replicaAntiAffinity(ctx context.Context, msvc *msv1alpha1.ModelService, role string) *corev1.PodAntiAffinity {
+	placement := rolePlacement(msvc, role)
+	if placement == nil || placement.ReplicaAntiAffinity == nil {
+		return nil
+	}
+	antiAffinity := placement.ReplicaAntiAffinity
+
+	topologyKey := antiAffinity.TopologyKey
+	if topologyKey == "" {
+		topologyKey = corev1.LabelHostname
+	}
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: placementPodLabels(ctx, msvc, role)},
+		TopologyKey:   topologyKey,
+	}
+	if antiAffinity.Required {
+		return &corev1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term}}
+	}
+
+	weight := antiAffinity.Weight
+	if weight == 0 {
+		weight = defaultPlacementWeight
+	}
+	return &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{Weight: weight, PodAffinityTerm: term}},
+	}
+}
+
+// topologySpreadConstraints returns the topology spread constraints of role,
+// selecting the pods of the role of the ModelService by default
+func topologySpreadConstraints(ctx context.Context, msvc *msv1alpha1.ModelService, role string) []corev1.TopologySpreadConstraint {
+	placement := rolePlacement(msvc, role)
+	if placement == nil {
+		return nil
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(placement.TopologySpreadConstraints))
+	for _, constraint := range placement.TopologySpreadConstraints {
+		constraint := *constraint.DeepCopy()
+		if constraint.LabelSelector == nil {
+			constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: placementPodLabels(ctx, msvc, role)}
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints
+}
+
+// mergeAffinity returns the affinity of the base config merged with the rendered
+// affinity. Required node affinity is added to every node selector term of the
+// base config, as terms are ORed; every other term is appended
+func mergeAffinity(base, rendered *corev1.Affinity) *corev1.Affinity {
+	if rendered == nil {
+		return base
+	}
+	if base == nil {
+		return rendered
+	}
+	merged := base.DeepCopy()
+
+	if rendered.NodeAffinity != nil {
+		if merged.NodeAffinity == nil {
+			merged.NodeAffinity = &corev1.NodeAffinity{}
+		}
+		merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = mergeNodeSelector(
+			merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			rendered.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		)
+		merged.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			rendered.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution...,
+		)
+	}
+
+	if rendered.PodAffinity != nil {
+		if merged.PodAffinity == nil {
+			merged.PodAffinity = &corev1.PodAffinity{}
+		}
+		merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			rendered.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...,
+		)
+		merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			rendered.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution...,
+		)
+	}
+
+	if rendered.PodAntiAffinity != nil {
+		if merged.PodAntiAffinity == nil {
+			merged.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			rendered.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...,
+		)
+		merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			rendered.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution...,
+		)
+	}
+	return merged
+}
+
+// mergeNodeSelector returns the node selector matching both base and rendered:
+// every term of rendered combined with every term of base
+func mergeNodeSelector(base, rendered *corev1.NodeSelector) *corev1.NodeSelector {
+	if rendered == nil || len(rendered.NodeSelectorTerms) == 0 {
+		return base
+	}
+	if base == nil || len(base.NodeSelectorTerms) == 0 {
+		return rendered.DeepCopy()
+	}
+
+	merged := &corev1.NodeSelector{}
+	for _, baseTerm := range base.NodeSelectorTerms {
+		for _, renderedTerm := range rendered.NodeSelectorTerms {
+			term := *baseTerm.DeepCopy()
+			term.MatchExpressions = append(term.MatchExpressions, renderedTerm.MatchExpressions...)
+			term.MatchFields = append(term.MatchFields, renderedTerm.MatchFields...)
+			merged.NodeSelectorTerms = append(merged.NodeSelectorTerms, term)
+		}
+	}
+	return merged
+}
+
+// mergeTopologySpreadConstraints returns the constraints of the base config with the
+// rendered constraints; a rendered constraint replaces the one of the same topology key
+func mergeTopologySpreadConstraints(base, rendered []corev1.TopologySpreadConstraint) []corev1.TopologySpreadConstraint {
+	if len(rendered) == 0 {
+		return base
+	}
+	merged := slices.DeleteFunc(slices.Clone(base), func(constraint corev1.TopologySpreadConstraint) bool {
+		return slices.ContainsFunc(rendered, func(r corev1.TopologySpreadConstraint) bool {
+			return r.TopologyKey == constraint.TopologyKey
+		})
+	})
+	return append(merged, rendered...)
+}
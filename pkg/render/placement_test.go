@@ -0,0 +1,147 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPlacement(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, msv1alpha1.AddToScheme(scheme))
+
+	zone := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1a"}}
+	gpu := corev1.NodeSelectorRequirement{Key: "nvidia.com/gpu.product", Operator: corev1.NodeSelectorOpIn, Values: []string{"H100"}}
+	spread := corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway}
+
+	tests := []struct {
+		name      string
+		placement *msv1alpha1.Placement
+		// affinity and topology spread constraints of the base config
+		base            *corev1.Affinity
+		baseConstraints []corev1.TopologySpreadConstraint
+		// expected node selector terms, pod affinity and anti-affinity of the decode pods
+		nodeTerms       []corev1.NodeSelectorTerm
+		podAffinity     *corev1.PodAffinity
+		podAntiAffinity *corev1.PodAntiAffinity
+		constraints     []corev1.TopologySpreadConstraint
+	}{
+		{
+			name:      "accelerator types without base config",
+			nodeTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}}},
+		},
+		{
+			name: "accelerator types required in every base config term",
+			base: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{zone}},
+				}},
+			}},
+			nodeTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{zone, gpu}}},
+		},
+		{
+			name: "pair affinity and preferred replica anti-affinity",
+			placement: &msv1alpha1.Placement{
+				Decode:       &msv1alpha1.RolePlacement{ReplicaAntiAffinity: &msv1alpha1.ReplicaAntiAffinity{}},
+				PairAffinity: &msv1alpha1.PairAffinity{TopologyKey: "nvidia.com/gpu.clique", Weight: 50},
+			},
+			base: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: corev1.LabelTopologyZone}},
+			}},
+			nodeTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}}},
+			podAffinity: &corev1.PodAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 50,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+						"llm-d.ai/inferenceServing": "true",
+						"llm-d.ai/model":            modelLabel(),
+						"llm-d.ai/role":             PREFILL_ROLE,
+						ModelServiceLabel:           msvcName,
+					}},
+					TopologyKey: "nvidia.com/gpu.clique",
+				},
+			}}},
+			podAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: corev1.LabelTopologyZone}},
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight: defaultPlacementWeight,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+							"llm-d.ai/inferenceServing": "true",
+							"llm-d.ai/model":            modelLabel(),
+							"llm-d.ai/role":             DECODE_ROLE,
+							ModelServiceLabel:           msvcName,
+						}},
+						TopologyKey: corev1.LabelHostname,
+					},
+				}},
+			},
+		},
+		{
+			name: "topology spread constraints replace the base config constraint of the same key",
+			placement: &msv1alpha1.Placement{
+				Decode: &msv1alpha1.RolePlacement{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{spread}},
+			},
+			baseConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 2, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule},
+				{MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+			nodeTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}}},
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+				{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+						"llm-d.ai/inferenceServing": "true",
+						"llm-d.ai/model":            modelLabel(),
+						"llm-d.ai/role":             DECODE_ROLE,
+						ModelServiceLabel:           msvcName,
+					}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.Decode = &msv1alpha1.PDSpec{
+				AcceleratorTypes: &msv1alpha1.AcceleratorTypes{LabelKey: gpu.Key, LabelValues: gpu.Values},
+			}
+			msvc.Spec.Placement = tt.placement
+
+			childResources := &ChildResources{DecodeDeployment: &appsv1.Deployment{}}
+			childResources.DecodeDeployment.Spec.Template.Spec.Affinity = tt.base
+			childResources.DecodeDeployment.Spec.Template.Spec.TopologySpreadConstraints = tt.baseConstraints
+			require.NoError(t, childResources.mergePDDeployment(ctx, msvc, DECODE_ROLE, scheme))
+
+			deployment := childResources.DecodeDeployment
+			affinity := deployment.Spec.Template.Spec.Affinity
+			require.NotNil(t, affinity)
+			assert.Equal(t, tt.nodeTerms, affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+			assert.Equal(t, tt.podAffinity, affinity.PodAffinity)
+			assert.Equal(t, tt.podAntiAffinity, affinity.PodAntiAffinity)
+			assert.Equal(t, tt.constraints, deployment.Spec.Template.Spec.TopologySpreadConstraints)
+
+			// the ModelService label is only on the pods of a ModelService with a placement
+			_, labeled := deployment.Spec.Template.Labels[ModelServiceLabel]
+			assert.Equal(t, tt.placement != nil, labeled)
+			assert.NotContains(t, deployment.Spec.Selector.MatchLabels, ModelServiceLabel)
+		})
+	}
+}
+
+// modelLabel returns the llm-d.ai/model label of minimalMSVC
+func modelLabel() string {
+	return getCommonLabels(context.Background(), minimalMSVC())["llm-d.ai/model"]
+}
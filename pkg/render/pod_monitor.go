@@ -0,0 +1,116 @@
+package render
+
+import (
+	"context"
+	"strconv"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Prometheus pod service discovery labels used to relabel the targets of the PodMonitor
+const (
+	containerPortNumberLabel = "__meta_kubernetes_pod_container_port_number"
+	podRoleLabel             = "__meta_kubernetes_pod_label_llm_d_ai_role"
+)
+
+// mergePodMonitor uses msvc fields to update the childResource PodMonitor.
+// The PodMonitor selects the pods of every prefill and decode deployment and
+// scrapes the port named by monitoring.port. Targets are labelled with the
+// model, role and modelservice
+func (childResources *ChildResources) mergePodMonitor(ctx context.Context, msvc *msv1alpha1.ModelService, scheme *runtime.Scheme) error {
+	// select the pods of the roles being deployed
+	var roles []string
+	if childResources.PrefillDeployment != nil {
+		roles = append(roles, PREFILL_ROLE)
+	}
+	if childResources.DecodeDeployment != nil {
+		roles = append(roles, DECODE_ROLE)
+	}
+	// there is nothing to scrape
+	if len(roles) == 0 {
+		childResources.PodMonitor = nil
+		return nil
+	}
+
+	if childResources.PodMonitor == nil {
+		childResources.PodMonitor = &monitoringv1.PodMonitor{}
+	}
+	dest := childResources.PodMonitor
+	name := PodMonitorName(msvc)
+
+	monitoring := msvc.Spec.Monitoring
+	if monitoring == nil {
+		monitoring = &msv1alpha1.Monitoring{}
+	}
+
+	dest.TypeMeta = metav1.TypeMeta{Kind: monitoringv1.PodMonitorsKind, APIVersion: monitoringv1.SchemeGroupVersion.String()}
+	dest.Name = name
+	dest.Namespace = msvc.Namespace
+	if dest.Labels == nil {
+		dest.Labels = map[string]string{}
+	}
+	for key, value := range getCommonLabels(ctx, msvc) {
+		dest.Labels[key] = value
+	}
+
+	dest.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: getCommonLabels(ctx, msvc),
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "llm-d.ai/role", Operator: metav1.LabelSelectorOpIn, Values: roles},
+		},
+	}
+	dest.Spec.NamespaceSelector = monitoringv1.NamespaceSelector{MatchNames: []string{msvc.Namespace}}
+
+	if len(dest.Spec.PodMetricsEndpoints) == 0 {
+		dest.Spec.PodMetricsEndpoints = []monitoringv1.PodMetricsEndpoint{{}}
+	}
+	for i := range dest.Spec.PodMetricsEndpoints {
+		endpoint := &dest.Spec.PodMetricsEndpoints[i]
+
+		if monitoring.Path != "" || endpoint.Path == "" {
+			endpoint.Path = DEFAULT_METRICS_PATH
+			if monitoring.Path != "" {
+				endpoint.Path = monitoring.Path
+			}
+		}
+		if monitoring.Interval != "" {
+			endpoint.Interval = monitoringv1.Duration(monitoring.Interval)
+		}
+
+		// the base config may name the container port itself;
+		// otherwise keep the targets on the routing port
+		if monitoring.Port != "" || endpoint.Port == "" {
+			portName := monitoring.Port
+			if portName == "" {
+				portName = DEFAULT_METRICS_PORT
+			}
+			port, err := getPort(msvc, portName)
+			if err != nil {
+				return &MergeError{Kind: monitoringv1.PodMonitorsKind, Name: name, Err: err}
+			}
+			endpoint.Port = ""
+			endpoint.RelabelConfigs = append(endpoint.RelabelConfigs, monitoringv1.RelabelConfig{
+				Action:       "keep",
+				SourceLabels: []monitoringv1.LabelName{containerPortNumberLabel},
+				Regex:        strconv.Itoa(int(port)),
+			})
+		}
+
+		endpoint.RelabelConfigs = append(endpoint.RelabelConfigs,
+			monitoringv1.RelabelConfig{Action: "replace", TargetLabel: "model", Replacement: ptr.To(msvc.Spec.Routing.ModelName)},
+			monitoringv1.RelabelConfig{Action: "replace", SourceLabels: []monitoringv1.LabelName{podRoleLabel}, TargetLabel: "role"},
+			monitoringv1.RelabelConfig{Action: "replace", TargetLabel: "modelservice", Replacement: ptr.To(msvc.Name)},
+		)
+	}
+
+	if err := controllerutil.SetOwnerReference(msvc, dest, scheme); err != nil {
+		return &MergeError{Kind: monitoringv1.PodMonitorsKind, Name: name, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package render
+
+import (
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodMonitor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, msv1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name       string
+		monitoring *msv1alpha1.Monitoring
+		prefill    bool
+		podMonitor string
+		check      func(t *testing.T, podMonitor *monitoringv1.PodMonitor)
+		errorMsg   string
+	}{
+		{
+			name: "no monitoring",
+			check: func(t *testing.T, podMonitor *monitoringv1.PodMonitor) {
+				assert.Nil(t, podMonitor)
+			},
+		},
+		{
+			name:       "defaults",
+			monitoring: &msv1alpha1.Monitoring{},
+			prefill:    true,
+			check: func(t *testing.T, podMonitor *monitoringv1.PodMonitor) {
+				assert.Equal(t, PodMonitorName(minimalMSVC()), podMonitor.Name)
+				assert.Equal(t, msvcNamespace, podMonitor.Namespace)
+				assert.Len(t, podMonitor.OwnerReferences, 1)
+				assert.Equal(t, []metav1.LabelSelectorRequirement{{
+					Key:      "llm-d.ai/role",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{PREFILL_ROLE, DECODE_ROLE},
+				}}, podMonitor.Spec.Selector.MatchExpressions)
+				assert.Equal(t, "true", podMonitor.Spec.Selector.MatchLabels["llm-d.ai/inferenceServing"])
+
+				require.Len(t, podMonitor.Spec.PodMetricsEndpoints, 1)
+				endpoint := podMonitor.Spec.PodMetricsEndpoints[0]
+				assert.Equal(t, DEFAULT_METRICS_PATH, endpoint.Path)
+				assert.Equal(t, []monitoringv1.RelabelConfig{
+					{Action: "keep", SourceLabels: []monitoringv1.LabelName{containerPortNumberLabel}, Regex: "8000"},
+					{Action: "replace", TargetLabel: "model", Replacement: ptr.To(modelName)},
+					{Action: "replace", SourceLabels: []monitoringv1.LabelName{podRoleLabel}, TargetLabel: "role"},
+					{Action: "replace", TargetLabel: "modelservice", Replacement: ptr.To(msvcName)},
+				}, endpoint.RelabelConfigs)
+			},
+		},
+		{
+			name:       "monitoring overrides the base config",
+			monitoring: &msv1alpha1.Monitoring{Port: "metrics", Path: "/stats", Interval: "15s"},
+			podMonitor: `metadata:
+  labels:
+    release: prometheus
+spec:
+  podMetricsEndpoints:
+  - port: vllm
+    path: /metrics
+    interval: 60s
+`,
+			check: func(t *testing.T, podMonitor *monitoringv1.PodMonitor) {
+				assert.Equal(t, "prometheus", podMonitor.Labels["release"])
+				endpoint := podMonitor.Spec.PodMetricsEndpoints[0]
+				assert.Equal(t, "/stats", endpoint.Path)
+				assert.Equal(t, monitoringv1.Duration("15s"), endpoint.Interval)
+				assert.Empty(t, endpoint.Port)
+				assert.Equal(t, "9000", endpoint.RelabelConfigs[0].Regex)
+			},
+		},
+		{
+			name: "base config names the container port",
+			podMonitor: `spec:
+  podMetricsEndpoints:
+  - port: vllm
+`,
+			check: func(t *testing.T, podMonitor *monitoringv1.PodMonitor) {
+				endpoint := podMonitor.Spec.PodMetricsEndpoints[0]
+				assert.Equal(t, "vllm", endpoint.Port)
+				assert.Equal(t, DEFAULT_METRICS_PATH, endpoint.Path)
+				assert.Len(t, endpoint.RelabelConfigs, 3)
+			},
+		},
+		{
+			name:       "undefined port",
+			monitoring: &msv1alpha1.Monitoring{Port: "missing"},
+			errorMsg:   `port "missing" is not defined in routing.ports`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{})
+			msvc.Spec.Routing.Ports = []msv1alpha1.Port{{Name: "app_port", Port: 8000}, {Name: "metrics", Port: 9000}}
+			msvc.Spec.Monitoring = tt.monitoring
+			if tt.prefill {
+				msvc.Spec.Prefill = &msv1alpha1.PDSpec{}
+			}
+			cm := &corev1.ConfigMap{Data: map[string]string{}}
+			if tt.podMonitor != "" {
+				cm.Data["podMonitor"] = tt.podMonitor
+			}
+
+			childResources, err := Render(t.Context(), msvc, cm, Options{Scheme: scheme})
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, childResources.PodMonitor)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodTemplateFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, msv1alpha1.AddToScheme(scheme))
+
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	spotToleration := corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule}
+
+	// base config pod template, shared by the decode and EPP deployments
+	baseDeployment := func() *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		deployment.Spec.Template.Labels = map[string]string{"team": "base", "tier": "serving"}
+		deployment.Spec.Template.Annotations = map[string]string{"sidecar.istio.io/inject": "false"}
+		deployment.Spec.Template.Spec = corev1.PodSpec{
+			NodeSelector:      map[string]string{"pool": "gpu", "zone": "a"},
+			Tolerations:       []corev1.Toleration{gpuToleration},
+			PriorityClassName: "default",
+			SchedulerName:     "default-scheduler",
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "quay"}},
+		}
+		return deployment
+	}
+	podSpec := msv1alpha1.ModelServicePodSpec{
+		PodLabels:         map[string]string{"team": "ml", "llm-d.ai/role": "other"},
+		PodAnnotations:    map[string]string{"owner": "ml-team"},
+		NodeSelector:      map[string]string{"zone": "b"},
+		Tolerations:       []corev1.Toleration{gpuToleration, spotToleration},
+		PriorityClassName: "inference-critical",
+		RuntimeClassName:  ptr.To("nvidia"),
+		SchedulerName:     "kai-scheduler",
+		ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "quay"}, {Name: "ghcr"}},
+	}
+
+	msvc := minimalMSVC()
+	msvc.Spec.Decode = &msv1alpha1.PDSpec{ModelServicePodSpec: podSpec}
+	msvc.Spec.EndpointPicker = podSpec.DeepCopy()
+	childResources := &ChildResources{DecodeDeployment: baseDeployment(), EPPDeployment: baseDeployment()}
+	require.NoError(t, childResources.mergePDDeployment(context.Background(), msvc, DECODE_ROLE, scheme))
+	require.NoError(t, childResources.mergeEppDeployment(msvc, scheme))
+
+	for role, deployment := range map[string]*appsv1.Deployment{DECODE_ROLE: childResources.DecodeDeployment, "epp": childResources.EPPDeployment} {
+		template := deployment.Spec.Template
+
+		// the ModelService overrides the base config, and the controller overrides the ModelService
+		assert.Equal(t, "ml", template.Labels["team"], role)
+		assert.Equal(t, "serving", template.Labels["tier"], role)
+		for key, value := range deployment.Spec.Selector.MatchLabels {
+			assert.Equal(t, value, template.Labels[key], role)
+		}
+		assert.Equal(t, map[string]string{"sidecar.istio.io/inject": "false", "owner": "ml-team"}, template.Annotations, role)
+
+		assert.Equal(t, map[string]string{"pool": "gpu", "zone": "b"}, template.Spec.NodeSelector, role)
+		assert.Equal(t, []corev1.Toleration{gpuToleration, spotToleration}, template.Spec.Tolerations, role)
+		assert.Equal(t, "inference-critical", template.Spec.PriorityClassName, role)
+		assert.Equal(t, ptr.To("nvidia"), template.Spec.RuntimeClassName, role)
+		assert.Equal(t, "kai-scheduler", template.Spec.SchedulerName, role)
+		assert.Equal(t, []corev1.LocalObjectReference{{Name: "quay"}, {Name: "ghcr"}}, template.Spec.ImagePullSecrets, role)
+	}
+	assert.Equal(t, DECODE_ROLE, childResources.DecodeDeployment.Spec.Template.Labels["llm-d.ai/role"])
+}
+
+func TestConvertToContainerSliceWithURIInfo(t *testing.T) {
+	msvc := minimalMSVC()
+	containers := convertToContainerSliceWithURIInfo([]msv1alpha1.ContainerSpec{{
+		Name:             "vllm",
+		MountModelVolume: true,
+		Env:              []corev1.EnvVar{{Name: "VLLM_LOGGING_LEVEL", Value: "DEBUG"}},
+		Ports:            []corev1.ContainerPort{{Name: "nixl", ContainerPort: 5557}},
+		VolumeMounts:     []corev1.VolumeMount{{Name: "shm", MountPath: "/dev/shm"}},
+		StartupProbe:     &corev1.Probe{FailureThreshold: 60},
+		SecurityContext:  &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)},
+	}}, msvc)
+	require.Len(t, containers, 1)
+
+	container := containers[0]
+	assert.Equal(t, []corev1.ContainerPort{{Name: "nixl", ContainerPort: 5557}}, container.Ports)
+	// the volume mounts of the container are kept with the model volume mounts
+	assert.Equal(t, append([]corev1.VolumeMount{{Name: "shm", MountPath: "/dev/shm"}}, getVolumeMountsForContainer(msvc)...), container.VolumeMounts)
+	// and so are its env vars, before the env vars of the model source
+	assert.Equal(t, append([]corev1.EnvVar{{Name: "VLLM_LOGGING_LEVEL", Value: "DEBUG"}}, getEnvsForContainer(msvc)...), container.Env)
+	assert.Equal(t, &corev1.Probe{FailureThreshold: 60}, container.StartupProbe)
+	assert.Equal(t, &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)}, container.SecurityContext)
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// propagationKey is the base config key holding the policy rolling out changes
+// of the base config to the ModelServices referencing it. It is not interpolated
+const propagationKey = "propagation"
+
+// Propagation returns the propagation policy declared by the base config cm,
+// or nil if it declares none
+func Propagation(cm *corev1.ConfigMap) (*msv1alpha1.PropagationPolicy, error) {
+	if cm == nil {
+		return nil, nil
+	}
+	raw, ok := cm.Data[propagationKey]
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	policy := &msv1alpha1.PropagationPolicy{}
+	if err := yaml.UnmarshalStrict([]byte(raw), policy); err != nil {
+		return nil, &DecodeError{Key: propagationKey, Line: errorLine(raw, err), Err: err}
+	}
+	if _, err := intstr.GetScaledValueFromIntOrPercent(&policy.MaxRolling, 1, true); err != nil {
+		return nil, &DecodeError{Key: propagationKey, Err: fmt.Errorf("maxRolling: %w", err)}
+	}
+	return policy, nil
+}
+
+// ChainPropagation returns the propagation policy of the last layer declaring one,
+// so that a base config overrides the policy of its parents, and the index of
+// that layer. The index is -1 if no layer declares a policy
+func ChainPropagation(layers []BaseConfigLayer) (*msv1alpha1.PropagationPolicy, int, error) {
+	for i := len(layers) - 1; i >= 0; i-- {
+		policy, err := Propagation(layers[i].ConfigMap)
+		if err != nil {
+			return nil, -1, err
+		}
+		if policy != nil {
+			return policy, i, nil
+		}
+	}
+	return nil, -1, nil
+}
+
+// BaseConfigRevision returns a short hash of the content of every layer, root first.
+// It changes whenever a layer is edited, and is empty if there is no layer
+func BaseConfigRevision(layers []BaseConfigLayer) string {
+	if len(layers) == 0 {
+		return ""
+	}
+
+	hash := sha256.New()
+	for _, layer := range layers {
+		fmt.Fprintf(hash, "%s\n", layer.Name)
+		if layer.ConfigMap == nil {
+			continue
+		}
+		keys := make([]string, 0, len(layer.ConfigMap.Data))
+		for key := range layer.ConfigMap.Data {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			fmt.Fprintf(hash, "%s=%q\n", key, layer.ConfigMap.Data[key])
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -0,0 +1,79 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestChainPropagation(t *testing.T) {
+	root := layeredConfigMap("platform", "root", "", map[string]string{propagationKey: "maxRolling: 25%\n"})
+	leaf := layeredConfigMap("team", "leaf", "name: root\nnamespace: platform\n", map[string]string{propagationKey: "maxRolling: 2\n"})
+	inherits := layeredConfigMap("team", "inherits", "name: root\nnamespace: platform\n", nil)
+
+	tests := []struct {
+		name   string
+		layers []BaseConfigLayer
+		want   *intstr.IntOrString
+		// index of the layer declaring the policy
+		layer    int
+		errorMsg string
+	}{
+		{
+			name:   "no policy",
+			layers: []BaseConfigLayer{{Name: "ConfigMap/team/plain", ConfigMap: layeredConfigMap("team", "plain", "", nil)}},
+			layer:  -1,
+		},
+		{
+			name:   "inherited from the parent",
+			layers: []BaseConfigLayer{{Name: "root", ConfigMap: root}, {Name: "inherits", ConfigMap: inherits}},
+			want:   ptr.To(intstr.FromString("25%")),
+		},
+		{
+			name:   "overridden by the child",
+			layers: []BaseConfigLayer{{Name: "root", ConfigMap: root}, {Name: "leaf", ConfigMap: leaf}},
+			want:   ptr.To(intstr.FromInt32(2)),
+			layer:  1,
+		},
+		{
+			name:     "invalid percentage",
+			layers:   []BaseConfigLayer{{Name: "bad", ConfigMap: layeredConfigMap("team", "bad", "", map[string]string{propagationKey: "maxRolling: half\n"})}},
+			errorMsg: "maxRolling",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, layer, err := ChainPropagation(tt.layers)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.layer, layer)
+			if tt.want == nil {
+				assert.Nil(t, policy)
+				return
+			}
+			require.NotNil(t, policy)
+			assert.Equal(t, *tt.want, policy.MaxRolling)
+		})
+	}
+}
+
+func TestBaseConfigRevision(t *testing.T) {
+	root := layeredConfigMap("platform", "root", "", map[string]string{"decodeDeployment": "spec:\n  replicas: 1\n"})
+	layers := []BaseConfigLayer{{Name: "ConfigMap/platform/root", ConfigMap: root}}
+
+	revision := BaseConfigRevision(layers)
+	assert.Len(t, revision, 16)
+	assert.Equal(t, revision, BaseConfigRevision(layers), "the revision is stable")
+	assert.Empty(t, BaseConfigRevision(nil))
+
+	edited := root.DeepCopy()
+	edited.Data["decodeDeployment"] = "spec:\n  replicas: 2\n"
+	assert.NotEqual(t, revision, BaseConfigRevision([]BaseConfigLayer{{Name: "ConfigMap/platform/root", ConfigMap: edited}}))
+}
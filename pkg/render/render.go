@@ -0,0 +1,130 @@
+// Package render turns a ModelService and its base config into the child
+// resources owned by the ModelService.
+//
+// The package has no dependency on a Kubernetes client; callers fetch the
+// base config themselves and apply the returned objects. Errors are
+// returned as *MissingTemplateVarsError, *TemplateError, *DecodeError or
+// *MergeError so that callers can tell which stage of the pipeline failed.
+package render
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// tracer records a span for each stage of Render
+var tracer = otel.Tracer("github.com/llm-d/llm-d-model-service/pkg/render")
+
+// RBACOptions provides the options need to create service accounts and
+// role binding during reconcile
+type RBACOptions struct {
+	// EPPPullSecrets contains names of epp pull secrets
+	// the secrets objects are assumed to be in the controller namespace
+	// these are pull secrets used by epp deployment created by the controller
+	EPPPullSecrets []string
+	// PDPullSecrets contains names of pd pull secrets;
+	// the secrets objects are assumed to be in the controller namespace
+	// these are pull secrets used by pd deployment created by the controller
+	PDPullSecrets []string
+	// EPPClusterRole name of the epp cluster role
+	// this is a cluster role used in the rolebinding for the epp deployment
+	EPPClusterRole string
+}
+
+// Options configures Render
+type Options struct {
+	// Scheme is used to set owner references on the child resources.
+	// It must know about the ModelService type
+	Scheme *runtime.Scheme
+	// RBACOptions configures the service accounts and role bindings
+	RBACOptions RBACOptions
+	// Parents are the ancestors of the base config, root first, as returned
+	// by ResolveBaseConfigChain. They are required if the base config
+	// declares a parent
+	Parents []BaseConfigLayer
+}
+
+// LoadBaseConfig interpolates the base config ConfigMap with the ModelService
+// template variables and decodes it.
+// A nil ConfigMap results in an empty BaseConfig
+func LoadBaseConfig(ctx context.Context, baseConfigMap *corev1.ConfigMap, msvc *msv1alpha1.ModelService) (*BaseConfig, error) {
+	if baseConfigMap == nil {
+		return &BaseConfig{}, nil
+	}
+
+	interpolated, err := InterpolateBaseConfigMap(ctx, baseConfigMap, msvc)
+	if err != nil {
+		return nil, err
+	}
+
+	return BaseConfigFromCM(interpolated)
+}
+
+// baseConfigLayers returns parents followed by baseConfigMap
+func baseConfigLayers(baseConfigMap *corev1.ConfigMap, parents []BaseConfigLayer) []BaseConfigLayer {
+	if baseConfigMap == nil {
+		return nil
+	}
+	return append(slices.Clone(parents), BaseConfigLayer{Name: baseConfigMap.Name, ConfigMap: baseConfigMap})
+}
+
+// Render returns the child resources for msvc, using baseConfigMap as the
+// base config. baseConfigMap may be nil.
+// msvc is not modified
+func Render(ctx context.Context, msvc *msv1alpha1.ModelService, baseConfigMap *corev1.ConfigMap, opts Options) (_ *ChildResources, err error) {
+	ctx, span := tracer.Start(ctx, "Render", trace.WithAttributes(tracing.ObjectAttributes("ModelService", msvc.Namespace, msvc.Name)...))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	layers := baseConfigLayers(baseConfigMap, opts.Parents)
+
+	// missing template variables are reported before anything is rendered
+	msvc, err = ResolveTemplateVars(layers, msvc)
+	if err != nil {
+		return nil, err
+	}
+
+	childResources, err := renderLayers(ctx, withoutAcceleratorOverrides(msvc), baseConfigMap, layers, opts)
+	if err != nil {
+		return nil, err
+	}
+	if childResources.PrefillAcceleratorDeployments, err = renderAcceleratorDeployments(ctx, msvc, PREFILL_ROLE, baseConfigMap, layers, opts); err != nil {
+		return nil, err
+	}
+	if childResources.DecodeAcceleratorDeployments, err = renderAcceleratorDeployments(ctx, msvc, DECODE_ROLE, baseConfigMap, layers, opts); err != nil {
+		return nil, err
+	}
+	return childResources, nil
+}
+
+// renderLayers interpolates msvc, whose template variables are resolved, and
+// the base config layers, then merges them into the child resources
+func renderLayers(ctx context.Context, msvc *msv1alpha1.ModelService, baseConfigMap *corev1.ConfigMap, layers []BaseConfigLayer, opts Options) (*ChildResources, error) {
+	interpolatedModelService, err := InterpolateModelService(ctx, msvc)
+	if err != nil {
+		return nil, err
+	}
+
+	baseConfig, err := LoadBaseConfigLayers(ctx, layers, interpolatedModelService)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Parents) == 0 && baseConfigMap != nil {
+		parent, err := ParentRef(baseConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			return nil, &DecodeError{Key: parentKey, Err: fmt.Errorf("parent %s is not resolved", parent.Name)}
+		}
+	}
+
+	return baseConfig.MergeChildResources(ctx, interpolatedModelService, opts.Scheme, &opts.RBACOptions)
+}
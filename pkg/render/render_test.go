@@ -0,0 +1,143 @@
+package render
+
+import (
+	"context"
+	"errors"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Render", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		msvc   *msv1alpha1.ModelService
+		cm     *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(msv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		msvc = createMSVCWithDecode(&msv1alpha1.PDSpec{
+			ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+				Containers: []msv1alpha1.ContainerSpec{
+					{
+						Name:             "vllm",
+						Args:             []string{"{{ .ModelPath }}"},
+						MountModelVolume: true,
+					},
+				},
+			},
+		})
+		msvc.Spec.Routing.Ports = []msv1alpha1.Port{{Name: "app_port", Port: 8000}}
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: msvcNamespace},
+			Data: map[string]string{
+				"decodeDeployment": `spec:
+  template:
+    spec:
+      containers:
+      - name: vllm
+        args:
+        - "--port={{ "app_port" | getPort }}"
+`,
+			},
+		}
+	})
+
+	It("should render child resources without a base config", func() {
+		cr, err := Render(ctx, msvc, nil, Options{Scheme: scheme})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cr.DecodeDeployment).ToNot(BeNil())
+		Expect(cr.DecodeDeployment.Name).To(Equal(DeploymentName(msvc, DECODE_ROLE)))
+		Expect(cr.PDServiceAccount).ToNot(BeNil())
+		Expect(cr.PrefillDeployment).To(BeNil())
+	})
+
+	It("should merge the interpolated modelservice into the base config", func() {
+		cr, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cr.DecodeDeployment).ToNot(BeNil())
+
+		containers := cr.DecodeDeployment.Spec.Template.Spec.Containers
+		Expect(containers).To(HaveLen(1))
+		Expect(containers[0].Args).To(Equal([]string{modelPath, "--port=8000"}))
+		Expect(cr.DecodeDeployment.OwnerReferences).To(HaveLen(1))
+	})
+
+	It("should render the interpolated env vars of the model container", func() {
+		msvc.Spec.Decode.Containers[0].Env = []corev1.EnvVar{{Name: "MODEL_PATH", Value: "{{ .ModelPath }}"}}
+		cr, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+		Expect(err).ToNot(HaveOccurred())
+
+		containers := cr.DecodeDeployment.Spec.Template.Spec.Containers
+		Expect(containers).To(HaveLen(1))
+		Expect(containers[0].Name).To(Equal("vllm"))
+		Expect(containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "MODEL_PATH", Value: modelPath}))
+	})
+
+	It("should not modify the modelservice", func() {
+		original := msvc.DeepCopy()
+		_, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(msvc).To(Equal(original))
+	})
+
+	It("should return a TemplateError for a bad base config template", func() {
+		cm.Data["decodeDeployment"] = "{{ .Missing"
+		_, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+		var templateErr *TemplateError
+		Expect(errors.As(err, &templateErr)).To(BeTrue())
+		Expect(templateErr.Key).To(Equal("decodeDeployment"))
+	})
+
+	It("should return a DecodeError for a bad base config key", func() {
+		cm.Data["decodeDeployment"] = "spec: [not, a, deployment, spec]"
+		_, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+		var decodeErr *DecodeError
+		Expect(errors.As(err, &decodeErr)).To(BeTrue())
+		Expect(decodeErr.Key).To(Equal("decodeDeployment"))
+	})
+
+	It("should return a MergeError when the scheme does not know ModelService", func() {
+		_, err := Render(ctx, msvc, cm, Options{Scheme: runtime.NewScheme()})
+		var mergeErr *MergeError
+		Expect(errors.As(err, &mergeErr)).To(BeTrue())
+		Expect(mergeErr.Kind).To(Equal("Deployment"))
+	})
+
+	It("should record a span for each stage", func() {
+		recorder := tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		DeferCleanup(otel.SetTracerProvider, previous)
+
+		cm.Data["decodeDeployment"] = "{{ .Missing"
+		_, err := Render(ctx, msvc, cm, Options{Scheme: scheme})
+		Expect(err).To(HaveOccurred())
+
+		spans := map[string]sdktrace.ReadOnlySpan{}
+		for _, span := range recorder.Ended() {
+			spans[span.Name()] = span
+		}
+		Expect(spans).To(HaveKey("InterpolateModelService"))
+		Expect(spans).To(HaveKey("LoadBaseConfigLayers"))
+		Expect(spans).To(HaveKey("InterpolateBaseConfigMap"))
+		Expect(spans["Render"].Status().Code).To(Equal(codes.Error))
+		Expect(spans["Render"].Attributes()).To(ContainElement(attribute.String("k8s.object.name", msvc.Name)))
+		Expect(spans["InterpolateBaseConfigMap"].Parent().SpanID()).To(Equal(spans["LoadBaseConfigLayers"].SpanContext().SpanID()))
+	})
+})
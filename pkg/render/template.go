@@ -0,0 +1,444 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	sprig "github.com/Masterminds/sprig/v3"
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/llm-d/llm-d-model-service/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TemplateVars is intended to be use for interpolating template variables
+// in BaseConfig
+type TemplateVars struct {
+	ModelServiceName      string `json:"modelServiceName,omitempty"`
+	ModelServiceNamespace string `json:"modelServiceNamespace,omitempty"`
+	ModelName             string `json:"modelName,omitempty"`
+	HFModelName           string `json:"hfModelName,omitempty"`
+	ModelRevision         string `json:"modelRevision,omitempty"`
+	SanitizedModelName    string `json:"sanitizedModelName,omitempty"`
+	ModelPath             string `json:"modelPath,omitempty"`
+	MountedModelPath      string `json:"mountedModelPath,omitempty"`
+	AuthSecretName        string `json:"authSecretName,omitempty"`
+	EPPServiceName        string `json:"eppServiceName,omitempty"`
+	EPPDeploymentName     string `json:"eppDeploymentName,omitempty"`
+	PrefillDeploymentName string `json:"prefillDeploymentName,omitempty"`
+	DecodeDeploymentName  string `json:"decodeDeploymentName,omitempty"`
+	PrefillServiceName    string `json:"prefillServiceName,omitempty"`
+	DecodeServiceName     string `json:"decodeServiceName,omitempty"`
+	InferencePoolName     string `json:"inferencePoolName,omitempty"`
+	InferenceModelName    string `json:"inferenceModelName,omitempty"`
+	// Vars holds ModelService.spec.templateVars, completed with the defaults
+	// declared by the base config
+	Vars map[string]interface{} `json:"vars,omitempty"`
+}
+
+// from populates the field values for TemplateVars from the model service
+func (t *TemplateVars) from(msvc *msv1alpha1.ModelService) error {
+	if t == nil {
+		return fmt.Errorf("nil templatevars")
+	}
+
+	// non empty template vars; attempt to populate
+	if msvc == nil {
+		return nil
+	}
+
+	t.ModelServiceName = msvc.Name
+	t.ModelServiceNamespace = msvc.Namespace
+	t.EPPServiceName = EPPServiceName(msvc)
+	t.EPPDeploymentName = EPPDeploymentName(msvc)
+	t.PrefillDeploymentName = DeploymentName(msvc, PREFILL_ROLE)
+	t.DecodeDeploymentName = DeploymentName(msvc, DECODE_ROLE)
+	t.PrefillServiceName = sanitizeSvcName(msvc, PREFILL_ROLE)
+	t.DecodeServiceName = sanitizeSvcName(msvc, DECODE_ROLE)
+	t.InferencePoolName = InferencePoolName(msvc)
+	t.InferenceModelName = InferenceModelName(msvc)
+	t.ModelName = msvc.Spec.Routing.ModelName
+	t.SanitizedModelName = sanitizeModelName(msvc)
+
+	vars, err := decodeTemplateVars(msvc)
+	if err != nil {
+		return err
+	}
+	t.Vars = vars
+
+	if msvc.Spec.ModelArtifacts.AuthSecretName != nil {
+		t.AuthSecretName = *msvc.Spec.ModelArtifacts.AuthSecretName
+	}
+
+	uri := msvc.Spec.ModelArtifacts.URI
+	source, ok := lookupArtifactSource(uri)
+	if !ok {
+		return fmt.Errorf("unsupported prefix for uri %q", uri)
+	}
+	if err := source.Parse(&msvc.Spec.ModelArtifacts); err != nil {
+		return err
+	}
+	if err := source.TemplateVars(msvc, t); err != nil {
+		return err
+	}
+
+	// Compute the mountedModelPath variable, given the URI type
+	// PVC: /model-cache/path/to/model
+	// HF: /model-cache
+	// S3, GCS: /model-cache/path/to/model
+	// OCI: /model-cache
+	mountedModelPath, err := source.MountedModelPath(msvc)
+	if err != nil {
+		return err
+	}
+	t.MountedModelPath = mountedModelPath
+
+	return nil
+
+}
+
+type TemplateFuncs struct {
+	funcMap template.FuncMap
+}
+
+// from populates the function map for TemplateFuncs from the model service.
+// Functions taking a role accept prefill, decode or epp (replicas only)
+func (t *TemplateFuncs) from(msvc *msv1alpha1.ModelService) {
+
+	// getPort fails the rendering if the port is not defined
+	t.funcMap["getPort"] = func(name string) (int32, error) {
+		return getPort(msvc, name)
+	}
+	t.funcMap["hasPort"] = func(name string) bool {
+		_, ok := routingPort(msvc, name)
+		return ok
+	}
+	// requirePort is getPort with a custom message, like sprig's required
+	t.funcMap["requirePort"] = func(msg string, name string) (int32, error) {
+		if p, ok := routingPort(msvc, name); ok {
+			return p, nil
+		}
+		return 0, fmt.Errorf("%s", msg)
+	}
+
+	// getReplicas returns the replicas of role, 0 if the role is not defined
+	t.funcMap["getReplicas"] = func(role string) (int32, error) {
+		var podSpec *msv1alpha1.ModelServicePodSpec
+		switch role {
+		case EPP_ROLE:
+			podSpec = msvc.Spec.EndpointPicker
+		default:
+			pdSpec, err := templatePDSpec(msvc, role)
+			if err != nil {
+				return 0, err
+			}
+			if pdSpec != nil {
+				podSpec = &pdSpec.ModelServicePodSpec
+			}
+		}
+		if podSpec == nil {
+			return 0, nil
+		}
+		if podSpec.Replicas == nil {
+			// the API server defaults replicas to 1
+			return 1, nil
+		}
+		return *podSpec.Replicas, nil
+	}
+
+	// getTensorParallelism returns the tensor parallelism of role, 1 if unset
+	t.funcMap["getTensorParallelism"] = func(role string) (int32, error) {
+		pdSpec, err := templatePDSpec(msvc, role)
+		if err != nil {
+			return 0, err
+		}
+		if pdSpec == nil || pdSpec.Parallelism == nil || pdSpec.Parallelism.Tensor == nil {
+			return 1, nil
+		}
+		return *pdSpec.Parallelism.Tensor, nil
+	}
+
+	// getAcceleratorLabelKey and getAcceleratorTypes return the accelerator types of role
+	t.funcMap["getAcceleratorLabelKey"] = func(role string) (string, error) {
+		pdSpec, err := templatePDSpec(msvc, role)
+		if err != nil || pdSpec == nil || pdSpec.AcceleratorTypes == nil {
+			return "", err
+		}
+		return pdSpec.AcceleratorTypes.LabelKey, nil
+	}
+	t.funcMap["getAcceleratorTypes"] = func(role string) ([]string, error) {
+		pdSpec, err := templatePDSpec(msvc, role)
+		if err != nil || pdSpec == nil || pdSpec.AcceleratorTypes == nil {
+			return []string{}, err
+		}
+		return pdSpec.AcceleratorTypes.LabelValues, nil
+	}
+
+	// getArtifactSize returns modelArtifacts.size, e.g. 10Gi, or "" if unset;
+	// getArtifactSizeBytes returns it in bytes, or 0 if unset
+	t.funcMap["getArtifactSize"] = func() string {
+		if msvc.Spec.ModelArtifacts.Size == nil {
+			return ""
+		}
+		return msvc.Spec.ModelArtifacts.Size.String()
+	}
+	t.funcMap["getArtifactSizeBytes"] = func() int64 {
+		if msvc.Spec.ModelArtifacts.Size == nil {
+			return 0
+		}
+		return msvc.Spec.ModelArtifacts.Size.Value()
+	}
+
+	// getURIScheme returns the scheme of modelArtifacts.uri, e.g. hf
+	t.funcMap["getURIScheme"] = func() string {
+		return string(UriType(msvc.Spec.ModelArtifacts.URI))
+	}
+
+	// getLabel and getAnnotation return the ModelService label or annotation key, or ""
+	t.funcMap["getLabel"] = func(key string) string {
+		return msvc.Labels[key]
+	}
+	t.funcMap["getAnnotation"] = func(key string) string {
+		return msvc.Annotations[key]
+	}
+}
+
+// routingPort returns the routing port of msvc named name
+func routingPort(msvc *msv1alpha1.ModelService, name string) (int32, bool) {
+	for _, p := range msvc.Spec.Routing.Ports {
+		if p.Name == name {
+			return p.Port, true
+		}
+	}
+	return 0, false
+}
+
+// getPort returns the routing port of msvc named name, or an error if it is not defined
+func getPort(msvc *msv1alpha1.ModelService, name string) (int32, error) {
+	if p, ok := routingPort(msvc, name); ok {
+		return p, nil
+	}
+	return 0, fmt.Errorf("port %q is not defined in routing.ports", name)
+}
+
+// templatePDSpec returns the prefill or decode section of msvc, or nil if
+// it is not defined. Other roles are an error
+func templatePDSpec(msvc *msv1alpha1.ModelService, role string) (*msv1alpha1.PDSpec, error) {
+	switch role {
+	case PREFILL_ROLE:
+		return msvc.Spec.Prefill, nil
+	case DECODE_ROLE:
+		return msvc.Spec.Decode, nil
+	default:
+		return nil, fmt.Errorf("unknown role %q; expected %s or %s", role, PREFILL_ROLE, DECODE_ROLE)
+	}
+}
+
+// registerSprigFunctions to get a new template with sprig functions support
+func registerSprigFunctions(tmplStr string, functions *TemplateFuncs) (*template.Template, error) {
+	// Create a new template and register Sprig functions
+	tmpl, err := template.New("template").
+		Funcs(sprig.TxtFuncMap()).
+		Funcs(functions.funcMap).
+		Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %w", err)
+	}
+	return tmpl, err
+}
+
+// templateEscape is rendered as a literal "{{"
+const templateEscape = `\{{`
+
+// renderTemplate using template vars
+func renderTemplate(tmplStr string, vars *TemplateVars, functions *TemplateFuncs) (string, error) {
+	tmplStr = strings.ReplaceAll(tmplStr, templateEscape, `{{ "{{" }}`)
+	tmpl, err := registerSprigFunctions(tmplStr, functions)
+	if err != nil {
+		return "", err
+	}
+
+	// Execute the template with the provided struct
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("error executing template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// templateContext returns the template variables and functions for msvc
+func templateContext(msvc *msv1alpha1.ModelService) (*TemplateVars, *TemplateFuncs, error) {
+	values := &TemplateVars{}
+	if err := values.from(msvc); err != nil {
+		return nil, nil, &TemplateError{Source: "modelservice", Err: err}
+	}
+
+	functions := &TemplateFuncs{funcMap: template.FuncMap{}}
+	functions.from(msvc)
+
+	return values, functions, nil
+}
+
+// InterpolateBaseConfigMap data strings using msvc template variable values
+func InterpolateBaseConfigMap(ctx context.Context, cm *corev1.ConfigMap, msvc *msv1alpha1.ModelService) (_ *corev1.ConfigMap, err error) {
+	_, span := tracer.Start(ctx, "InterpolateBaseConfigMap", trace.WithAttributes(tracing.ObjectAttributes("ConfigMap", cm.Namespace, cm.Name)...))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	values, functions, err := templateContext(msvc)
+	if err != nil {
+		return nil, err
+	}
+
+	// interpolate base config data
+	interpolated := cm.DeepCopy()
+	for key, tmplStr := range interpolated.Data {
+		// declarations hold literal defaults; overlays use CEL expressions instead
+		if key == templateVarsKey || key == overlaysKey || key == propagationKey {
+			continue
+		}
+		// render first time with the user-exposed values;
+		// these values can be used to interpolate user-defined base config templates
+		rendering, err := renderTemplate(tmplStr, values, functions)
+		if err != nil {
+			return nil, &TemplateError{Source: "baseconfig", Key: key, Err: err}
+		}
+
+		interpolated.Data[key] = rendering
+	}
+
+	// structured overlays apply on top of the rendered templates
+	if err := applyOverlays(interpolated, msvc, values); err != nil {
+		return nil, err
+	}
+
+	return interpolated, nil
+}
+
+// interpolateStrings renders every string reachable from v in place.
+// v must be addressable; path names v in errors, e.g. decode.containers[vllm].env[MODEL].value
+func interpolateStrings(v reflect.Value, path string, values *TemplateVars, functions *TemplateFuncs) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return interpolateStrings(v.Elem(), path, values, functions)
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if err := interpolateStrings(v.Field(i), fieldPath(path, field), values, functions); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		for i := range v.Len() {
+			if err := interpolateStrings(v.Index(i), itemPath(path, v.Index(i), i), values, functions); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		// only maps of strings, such as labels; map values are not addressable
+		elemType := v.Type().Elem()
+		if elemType.Kind() != reflect.String {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			rendered, err := interpolateString(iter.Value().String(), fmt.Sprintf("%s[%v]", path, iter.Key()), values, functions)
+			if err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), reflect.ValueOf(rendered).Convert(elemType))
+		}
+
+	case reflect.String:
+		rendered, err := interpolateString(v.String(), path, values, functions)
+		if err != nil {
+			return err
+		}
+		v.SetString(rendered)
+	}
+	return nil
+}
+
+// interpolateString renders str; strings without a template action are returned as is
+func interpolateString(str string, path string, values *TemplateVars, functions *TemplateFuncs) (string, error) {
+	if !strings.Contains(str, "{{") {
+		return str, nil
+	}
+	rendered, err := renderTemplate(str, values, functions)
+	if err != nil {
+		return "", &TemplateError{Source: "modelservice", Key: path, Err: err}
+	}
+	return rendered, nil
+}
+
+// fieldPath returns the path of field below path, using its json name.
+// Inlined fields share the path of their parent
+func fieldPath(path string, field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if field.Anonymous && name == "" {
+		return path
+	}
+	if name == "" {
+		name = field.Name
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// itemPath returns the path of the list item at index i below path,
+// identified by its name if it has one
+func itemPath(path string, item reflect.Value, i int) string {
+	if item.Kind() == reflect.Struct {
+		if name := item.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String && name.String() != "" {
+			return fmt.Sprintf("%s[%s]", path, name.String())
+		}
+	}
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// InterpolateModelService interpolates every string of the prefill, decode and
+// endpointPicker sections using msvc template variable values.
+// routing, modelArtifacts and baseConfigMapRef define the template variables
+// and are not interpolated
+func InterpolateModelService(ctx context.Context, msvc *msv1alpha1.ModelService) (_ *msv1alpha1.ModelService, err error) {
+	_, span := tracer.Start(ctx, "InterpolateModelService", trace.WithAttributes(tracing.ObjectAttributes("ModelService", msvc.Namespace, msvc.Name)...))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	values, functions, err := templateContext(msvc)
+	if err != nil {
+		return nil, err
+	}
+
+	msvcCopy := msvc.DeepCopy()
+	sections := []struct {
+		path string
+		spec interface{}
+	}{
+		{path: PREFILL_ROLE, spec: msvcCopy.Spec.Prefill},
+		{path: DECODE_ROLE, spec: msvcCopy.Spec.Decode},
+		{path: "endpointPicker", spec: msvcCopy.Spec.EndpointPicker},
+	}
+	for _, section := range sections {
+		if err := interpolateStrings(reflect.ValueOf(section.spec), section.path, values, functions); err != nil {
+			return nil, err
+		}
+	}
+
+	return msvcCopy, nil
+}
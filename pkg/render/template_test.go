@@ -1,4 +1,4 @@
-package controller
+package render
 
 import (
 	"context"
@@ -7,7 +7,11 @@ import (
 
 	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 const msvcName = "msvc-test"
@@ -16,7 +20,7 @@ const modelName = "modelName"
 const sanitizedModelName = "modelname"
 const pvcName = "pvc-name"
 const modelPath = "path/to/" + modelName
-const mountedModelPathInVolume = modelStorageRoot + pathSep + modelPath
+const mountedModelPathInVolume = ModelStorageRoot + pathSep + modelPath
 const pvcURI = "pvc://" + pvcName + "/" + modelPath
 const hfModelName = pvcName + "/" + modelName
 const hfURI = "hf://" + hfModelName
@@ -246,3 +250,125 @@ func TestMSVCInterpolation(t *testing.T) {
 		})
 	}
 }
+
+func TestMSVCInterpolationAllStrings(t *testing.T) {
+	image := "registry/{{ .SanitizedModelName }}:latest"
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{
+		ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+			Containers: []msv1alpha1.ContainerSpec{
+				{
+					Name:    "vllm",
+					Image:   &image,
+					Command: []string{"serve", "{{ .ModelPath }}"},
+					Env: []corev1.EnvVar{
+						{Name: "MODEL_PATH", Value: "{{ .MountedModelPath }}"},
+						{Name: "LITERAL", Value: `\{{ not a template }}`},
+					},
+				},
+			},
+		},
+	})
+	msvc.Spec.Routing.Ports = []msv1alpha1.Port{{Name: "app_port", Port: 8000}}
+	msvc.Spec.EndpointPicker = &msv1alpha1.ModelServicePodSpec{
+		Containers: []msv1alpha1.ContainerSpec{
+			{Name: "epp", Args: []string{`--port={{ "app_port" | getPort }}`, "--pool={{ .InferencePoolName }}"}},
+		},
+	}
+
+	interpolated, err := InterpolateModelService(context.Background(), msvc)
+	require.NoError(t, err)
+
+	container := interpolated.Spec.Decode.Containers[0]
+	assert.Equal(t, "registry/"+sanitizedModelName+":latest", *container.Image)
+	assert.Equal(t, []string{"serve", modelPath}, container.Command)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "MODEL_PATH", Value: mountedModelPathInVolume},
+		{Name: "LITERAL", Value: "{{ not a template }}"},
+	}, container.Env)
+	assert.Equal(t, []string{"--port=8000", "--pool=" + InferencePoolName(msvc)}, interpolated.Spec.EndpointPicker.Containers[0].Args)
+
+	// the original is left untouched
+	assert.Equal(t, "registry/{{ .SanitizedModelName }}:latest", *msvc.Spec.Decode.Containers[0].Image)
+}
+
+func TestMSVCInterpolationErrorPath(t *testing.T) {
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{
+		ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{
+			Containers: []msv1alpha1.ContainerSpec{
+				{
+					Name: "vllm",
+					Env:  []corev1.EnvVar{{Name: "BROKEN", Value: "{{ .Unknown }}"}},
+				},
+			},
+		},
+	})
+
+	_, err := InterpolateModelService(context.Background(), msvc)
+	var templateErr *TemplateError
+	require.ErrorAs(t, err, &templateErr)
+	assert.Equal(t, "decode.containers[vllm].env[BROKEN].value", templateErr.Key)
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{
+		ModelServicePodSpec: msv1alpha1.ModelServicePodSpec{Replicas: ptr.To(int32(3))},
+		Parallelism:         &msv1alpha1.Parallelism{Tensor: ptr.To(int32(4))},
+		AcceleratorTypes: &msv1alpha1.AcceleratorTypes{
+			LabelKey:    "nvidia.com/gpu.product",
+			LabelValues: []string{"H100", "A100"},
+		},
+	})
+	msvc.Labels = map[string]string{"team": "research"}
+	msvc.Annotations = map[string]string{"owner": "alice"}
+	msvc.Spec.Routing.Ports = []msv1alpha1.Port{{Name: "app_port", Port: 8000}}
+	msvc.Spec.ModelArtifacts.Size = ptr.To(resource.MustParse("2Gi"))
+
+	tests := []struct {
+		tmpl     string
+		expected string
+		errorMsg string
+	}{
+		{tmpl: `{{ "app_port" | getPort }}`, expected: "8000"},
+		{tmpl: `{{ "nixl_port" | getPort }}`, errorMsg: `port "nixl_port" is not defined in routing.ports`},
+		{tmpl: `{{ requirePort "set a nixl_port" "nixl_port" }}`, errorMsg: "set a nixl_port"},
+		{tmpl: `{{ hasPort "app_port" }} {{ hasPort "nixl_port" }}`, expected: "true false"},
+		{tmpl: `{{ getReplicas "decode" }} {{ getReplicas "prefill" }} {{ getReplicas "epp" }}`, expected: "3 0 0"},
+		{tmpl: `{{ getReplicas "router" }}`, errorMsg: `unknown role "router"`},
+		{tmpl: `{{ getTensorParallelism "decode" }} {{ getTensorParallelism "prefill" }}`, expected: "4 1"},
+		{tmpl: `{{ mul 64 (getTensorParallelism "decode") }}`, expected: "256"},
+		{tmpl: `{{ getAcceleratorLabelKey "decode" }}={{ getAcceleratorTypes "decode" | join "," }}`, expected: "nvidia.com/gpu.product=H100,A100"},
+		{tmpl: `[{{ getAcceleratorTypes "prefill" | join "," }}]`, expected: "[]"},
+		{tmpl: `{{ getArtifactSize }} {{ getArtifactSizeBytes }}`, expected: "2Gi 2147483648"},
+		{tmpl: `{{ getURIScheme }}`, expected: "pvc"},
+		{tmpl: `{{ getLabel "team" }} {{ getAnnotation "owner" }} [{{ getLabel "missing" }}]`, expected: "research alice []"},
+	}
+
+	_, functions, err := templateContext(msvc)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			rendered, err := renderTemplate(tt.tmpl, &TemplateVars{}, functions)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rendered)
+		})
+	}
+}
+
+func TestObjectStorageTemplateVars(t *testing.T) {
+	for uri, expected := range map[string]string{
+		"s3://models/llama/3.1-8b": "llama/3.1-8b",
+		"gs://models/opt-125m/":    "opt-125m",
+	} {
+		msvc := minimalMSVC()
+		msvc.Spec.ModelArtifacts.URI = uri
+
+		vars := &TemplateVars{}
+		require.NoError(t, vars.from(msvc), uri)
+		assert.Equal(t, expected, vars.ModelPath, uri)
+		assert.Equal(t, ModelStorageRoot+"/"+expected, vars.MountedModelPath, uri)
+	}
+}
@@ -0,0 +1,100 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// templateVarsKey is the base config key declaring the ModelService template
+// variables used by the base config. It is not interpolated
+const templateVarsKey = "templateVars"
+
+// MissingTemplateVarsError is returned when a ModelService does not set
+// template variables that its base config requires
+type MissingTemplateVarsError struct {
+	// Names of the missing variables, sorted
+	Names []string
+}
+
+func (e *MissingTemplateVarsError) Error() string {
+	return fmt.Sprintf("missing required template variables: %s", strings.Join(e.Names, ", "))
+}
+
+// TemplateVarDeclarations returns the template variables declared by the
+// base config cm, or nil if it declares none
+func TemplateVarDeclarations(cm *corev1.ConfigMap) (map[string]msv1alpha1.TemplateVarDeclaration, error) {
+	if cm == nil {
+		return nil, nil
+	}
+	raw, ok := cm.Data[templateVarsKey]
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	declarations := map[string]msv1alpha1.TemplateVarDeclaration{}
+	if err := yaml.UnmarshalStrict([]byte(raw), &declarations); err != nil {
+		return nil, &DecodeError{Key: templateVarsKey, Line: errorLine(raw, err), Err: err}
+	}
+	return declarations, nil
+}
+
+// ResolveTemplateVars returns a copy of msvc whose template variables are
+// completed with the defaults declared by the base config layers, root first.
+// A layer's declaration replaces the one of its parent.
+// It returns a MissingTemplateVarsError if a required variable is not set
+func ResolveTemplateVars(layers []BaseConfigLayer, msvc *msv1alpha1.ModelService) (*msv1alpha1.ModelService, error) {
+	declarations := map[string]msv1alpha1.TemplateVarDeclaration{}
+	for _, layer := range layers {
+		layerDeclarations, err := TemplateVarDeclarations(layer.ConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		for name, declaration := range layerDeclarations {
+			declarations[name] = declaration
+		}
+	}
+
+	resolved := msvc.DeepCopy()
+	var missing []string
+	for name, declaration := range declarations {
+		if _, ok := resolved.Spec.TemplateVars[name]; ok {
+			continue
+		}
+		if declaration.Default != nil {
+			if resolved.Spec.TemplateVars == nil {
+				resolved.Spec.TemplateVars = map[string]apiextensionsv1.JSON{}
+			}
+			resolved.Spec.TemplateVars[name] = *declaration.Default.DeepCopy()
+			continue
+		}
+		if declaration.Required {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return nil, &MissingTemplateVarsError{Names: missing}
+	}
+	return resolved, nil
+}
+
+// decodeTemplateVars returns the template variables of msvc as generic values
+func decodeTemplateVars(msvc *msv1alpha1.ModelService) (map[string]interface{}, error) {
+	vars := make(map[string]interface{}, len(msvc.Spec.TemplateVars))
+	for name, value := range msvc.Spec.TemplateVars {
+		var decoded interface{}
+		if err := json.Unmarshal(value.Raw, &decoded); err != nil {
+			return nil, fmt.Errorf("invalid template variable %q: %w", name, err)
+		}
+		vars[name] = decoded
+	}
+	return vars, nil
+}
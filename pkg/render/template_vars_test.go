@@ -0,0 +1,121 @@
+package render
+
+import (
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestResolveTemplateVars(t *testing.T) {
+	platform := &corev1.ConfigMap{Data: map[string]string{
+		templateVarsKey: `maxNumSeqs:
+  default: 128
+team:
+  required: true
+  description: team owning the model
+`,
+	}}
+	profile := &corev1.ConfigMap{Data: map[string]string{
+		templateVarsKey: `maxNumSeqs:
+  default: 256
+`,
+	}}
+	layers := []BaseConfigLayer{{Name: "platform", ConfigMap: platform}, {Name: "profile", ConfigMap: profile}}
+
+	tests := []struct {
+		name     string
+		vars     map[string]apiextensionsv1.JSON
+		expected map[string]string
+		missing  []string
+	}{
+		{
+			name:    "missing required variable",
+			missing: []string{"team"},
+		},
+		{
+			name:     "defaults of the child replace the parent's",
+			vars:     map[string]apiextensionsv1.JSON{"team": {Raw: []byte(`"research"`)}},
+			expected: map[string]string{"team": `"research"`, "maxNumSeqs": "256"},
+		},
+		{
+			name: "the ModelService takes precedence over defaults",
+			vars: map[string]apiextensionsv1.JSON{
+				"team":       {Raw: []byte(`"research"`)},
+				"maxNumSeqs": {Raw: []byte(`64`)},
+			},
+			expected: map[string]string{"team": `"research"`, "maxNumSeqs": "64"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.TemplateVars = tt.vars
+
+			resolved, err := ResolveTemplateVars(layers, msvc)
+			if tt.missing != nil {
+				var missingErr *MissingTemplateVarsError
+				require.ErrorAs(t, err, &missingErr)
+				assert.Equal(t, tt.missing, missingErr.Names)
+				return
+			}
+			require.NoError(t, err)
+
+			got := map[string]string{}
+			for name, value := range resolved.Spec.TemplateVars {
+				got[name] = string(value.Raw)
+			}
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.vars, msvc.Spec.TemplateVars, "the ModelService is not modified")
+		})
+	}
+}
+
+func TestTemplateVarsInBaseConfig(t *testing.T) {
+	cm := &corev1.ConfigMap{Data: map[string]string{
+		templateVarsKey: `maxNumSeqs:
+  default: 128
+`,
+		"decodeDeployment": `spec:
+  template:
+    metadata:
+      labels:
+        team: "{{ .Vars.team }}"
+    spec:
+      containers:
+      - name: vllm
+        args:
+        - "--max-num-seqs={{ .Vars.maxNumSeqs }}"
+        - "--tags={{ .Vars.tags | join "," }}"
+`,
+	}}
+	msvc := createMSVCWithDecode(&msv1alpha1.PDSpec{})
+	msvc.Spec.TemplateVars = map[string]apiextensionsv1.JSON{
+		"team": {Raw: []byte(`"research"`)},
+		"tags": {Raw: []byte(`["a","b"]`)},
+	}
+
+	resolved, err := ResolveTemplateVars([]BaseConfigLayer{{ConfigMap: cm}}, msvc)
+	require.NoError(t, err)
+	bc, err := LoadBaseConfig(t.Context(), cm, resolved)
+	require.NoError(t, err)
+
+	template := bc.DecodeDeployment.Spec.Template
+	assert.Equal(t, "research", template.Labels["team"])
+	assert.Equal(t, []string{"--max-num-seqs=128", "--tags=a,b"}, template.Spec.Containers[0].Args)
+}
+
+func TestTemplateVarDeclarationsStrict(t *testing.T) {
+	cm := &corev1.ConfigMap{Data: map[string]string{
+		templateVarsKey: "team:\n  requred: true\n",
+	}}
+	_, err := BaseConfigFromCM(cm)
+	var decodeErrs DecodeErrors
+	require.ErrorAs(t, err, &decodeErrs)
+	assert.Equal(t, templateVarsKey, decodeErrs[0].Key)
+	assert.Empty(t, UnknownBaseConfigKeys(cm))
+}
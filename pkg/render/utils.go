@@ -0,0 +1,315 @@
+package render
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+)
+
+// DeploymentName returns the name that should be used for a deployment object
+func DeploymentName(modelService *msv1alpha1.ModelService, role string) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-" + role)
+	if err != nil {
+		return "deployment-" + role
+	}
+	return sanitizedName
+}
+
+// HTTPRouteName returns the name of the http route object
+func HTTPRouteName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-http-route")
+	if err != nil {
+		return "http-route"
+	}
+
+	return sanitizedName
+}
+
+// PodMonitorName returns the name of the pod monitor object
+func PodMonitorName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-pod-monitor")
+	if err != nil {
+		return "pod-monitor"
+	}
+
+	return sanitizedName
+}
+
+// ModelCachePVCName returns the name of the persistent volume claim caching the model
+func ModelCachePVCName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-model-cache")
+	if err != nil {
+		return "model-cache"
+	}
+
+	return sanitizedName
+}
+
+// ModelDownloadJobName returns the name of the job populating the model cache
+func ModelDownloadJobName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-model-download")
+	if err != nil {
+		return "model-download"
+	}
+
+	return sanitizedName
+}
+
+// InferencePoolName returns the name of the inference pool object
+func InferencePoolName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-inference-pool")
+	if err != nil {
+		return "inference-pool"
+	}
+
+	return sanitizedName
+}
+
+// EPPDeploymentName returns the name of the epp deployment object
+func EPPDeploymentName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-epp")
+	if err != nil {
+		return "epp-deployment"
+	}
+
+	return sanitizedName
+}
+
+// EPPServiceName returns the name of the epp service object
+func EPPServiceName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-epp-service")
+	if err != nil {
+		return "epp-service"
+	}
+	return sanitizedName
+}
+
+// PDServiceAccountName returns the name of the service account for the prefill and decode pods
+func PDServiceAccountName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-sa")
+	if err != nil {
+		return "pd-sa"
+	}
+	return sanitizedName
+}
+
+// EPPServiceAccountName returns the name of the epp service account object
+// defaults it to "epp-sa"
+func EPPServiceAccountName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-epp-sa")
+	if err != nil {
+		return "epp-sa"
+	}
+	return sanitizedName
+}
+
+// EPPRoleBindingName returns the name of the epp rolebinding object
+// defaults it to "epp-rolebinding"
+func EPPRoleBindingName(modelService *msv1alpha1.ModelService) string {
+	sanitizedName, err := sanitizeName(modelService.Name + "-epp-rolebinding")
+	if err != nil {
+		return "epp-rolebinding"
+	}
+	return sanitizedName
+}
+
+// InferenceModelName returns the name of the inference model object
+func InferenceModelName(modelService *msv1alpha1.ModelService) string {
+	return modelService.Name
+}
+
+// mountedModelPath returns the mounted model path for the specific URI type
+func mountedModelPath(modelService *msv1alpha1.ModelService) (string, error) {
+	source, ok := lookupArtifactSource(modelService.Spec.ModelArtifacts.URI)
+	if !ok {
+		return "", fmt.Errorf("unknown uri type, cannot compute the mountedModelPath")
+	}
+	return source.MountedModelPath(modelService)
+}
+
+// UriType returns the type of URI, the scheme of its registered artifact source
+func UriType(uri string) URIType {
+	if source, ok := lookupArtifactSource(uri); ok {
+		return URIType(source.Scheme())
+	}
+	return UnknownURI
+}
+
+// getVolumeMountForContainer returns a VolumeMount for a container where MountModelVolume: true
+func getVolumeMountsForContainer(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+	// unknown URIs are rejected when the template vars are computed
+	if source, ok := lookupArtifactSource(msvc.Spec.ModelArtifacts.URI); ok {
+		volumeMounts = append(volumeMounts, source.VolumeMounts(msvc)...)
+	}
+	return append(volumeMounts, writableCacheMounts(msvc)...)
+}
+
+// getVolumeForPDDeployment returns a Volume for ModelArtifacts.URI
+func getVolumeForPDDeployment(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	volumes := []corev1.Volume{}
+	// unknown URIs are rejected when the template vars are computed
+	if source, ok := lookupArtifactSource(msvc.Spec.ModelArtifacts.URI); ok {
+		volumes = append(volumes, source.Volumes(msvc)...)
+	}
+	volumes = append(volumes, writableCacheVolumes(msvc)...)
+	return append(volumes, modelVerificationVolumes(msvc)...)
+}
+
+// getEnvsForContainer returns the desired list of env vars for the container for the given URI type
+func getEnvsForContainer(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	envs := []corev1.EnvVar{}
+	// unknown URIs are rejected when the template vars are computed
+	if source, ok := lookupArtifactSource(msvc.Spec.ModelArtifacts.URI); ok {
+		envs = append(envs, source.Env(msvc)...)
+	}
+	return append(envs, writableCacheEnvs(msvc)...)
+}
+
+// AuthSecretKeys returns the name of modelArtifacts.authSecretName and the keys the
+// rendered containers require from it, and false if the Secret is not used
+func AuthSecretKeys(msvc *msv1alpha1.ModelService) (string, []string, bool) {
+	artifacts := msvc.Spec.ModelArtifacts
+	if artifacts.AuthSecretName == nil || (isHFURI(artifacts.URI) && hfTokenFromFile(msvc)) {
+		return "", nil, false
+	}
+	secretName := *artifacts.AuthSecretName
+
+	var envs []corev1.EnvVar
+	if source, ok := lookupArtifactSource(artifacts.URI); ok {
+		envs = append(envs, source.Env(msvc)...)
+	}
+	if downloader, ok := lookupArtifactDownloader(artifacts.URI); ok {
+		envs = append(envs, downloader.DownloadEnv(msvc)...)
+	}
+
+	var keys []string
+	for _, env := range envs {
+		if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		ref := env.ValueFrom.SecretKeyRef
+		if ref.Name == secretName && !ptr.Deref(ref.Optional, false) && !slices.Contains(keys, ref.Key) {
+			keys = append(keys, ref.Key)
+		}
+	}
+	return secretName, keys, true
+}
+
+// secretKeyEnv returns the env var name read from the key of the same name in the secret secretName
+func secretKeyEnv(name, secretName string, optional bool) corev1.EnvVar {
+	env := corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: name,
+			},
+		},
+	}
+	if optional {
+		env.ValueFrom.SecretKeyRef.Optional = &optional
+	}
+	return env
+}
+
+// sanitizeName converts an routing.ModelNAme into a valid Kubernetes label value
+func sanitizeName(s string) (string, error) {
+	// Convert to lower case and trim spaces
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	// Replace any disallowed characters with `-`
+	re := regexp.MustCompile(`[^a-z0-9-]+`)
+	s = re.ReplaceAllString(s, "-")
+
+	// Trim leading/trailing non-alphanumerics
+	s = strings.Trim(s, "-._")
+
+	// Enforce length limit
+	if len(s) > 63 {
+		s = s[:63]
+	}
+
+	// Final check
+	if len(validation.IsValidLabelValue(s)) > 0 {
+		return "", fmt.Errorf("cannot sanitize into a valid DNS compliant name")
+	}
+
+	return s, nil
+}
+
+// convertToContainerSlice converts []Containers to []corev1.Container
+// Note we lose information about MountModelVolume, ok for EndpointPicker container
+// but not ok for PDSpec containers
+func convertToContainerSlice(c []msv1alpha1.ContainerSpec) []corev1.Container {
+
+	containerSlice := make([]corev1.Container, len(c))
+
+	for i, containerSpec := range c {
+		containerSlice[i] = corev1.Container{
+			Name:            containerSpec.Name,
+			Command:         containerSpec.Command,
+			Args:            containerSpec.Args,
+			Env:             containerSpec.Env,
+			EnvFrom:         containerSpec.EnvFrom,
+			Resources:       containerSpec.Resources,
+			Ports:           containerSpec.Ports,
+			VolumeMounts:    containerSpec.VolumeMounts,
+			LivenessProbe:   containerSpec.LivenessProbe,
+			ReadinessProbe:  containerSpec.ReadinessProbe,
+			StartupProbe:    containerSpec.StartupProbe,
+			SecurityContext: containerSpec.SecurityContext,
+		}
+
+		if containerSpec.Image != nil {
+			containerSlice[i].Image = *containerSpec.Image
+		}
+	}
+
+	return containerSlice
+}
+
+// convertToContainerSliceWithURIInfo converts []Containers to []corev1.Container
+// with the relevant volumeMount and env for containers where mountModelPath is true
+// c is the targeted container slice (can be initContainer or Container)
+// msvc is the msvc so we can get the URI and populate the relevant volumeMount and env
+func convertToContainerSliceWithURIInfo(c []msv1alpha1.ContainerSpec, msvc *msv1alpha1.ModelService) []corev1.Container {
+
+	containerSlice := convertToContainerSlice(c)
+	for i := range c {
+		if c[i].MountModelVolume {
+			containerSlice[i].Env = append(slices.Clone(c[i].Env), getEnvsForContainer(msvc)...)
+			containerSlice[i].VolumeMounts = append(slices.Clone(c[i].VolumeMounts), getVolumeMountsForContainer(msvc)...)
+		}
+	}
+
+	return containerSlice
+}
+
+// setPodTemplateFields sets the pod-level fields of podSpec on template. labels
+// are the labels set by the controller, over the podLabels of podSpec
+func setPodTemplateFields(template *corev1.PodTemplateSpec, podSpec *msv1alpha1.ModelServicePodSpec, labels map[string]string) {
+	podLabels := maps.Clone(podSpec.PodLabels)
+	if podLabels == nil {
+		podLabels = map[string]string{}
+	}
+	maps.Copy(podLabels, labels)
+	template.Labels = podLabels
+	template.Annotations = podSpec.PodAnnotations
+
+	template.Spec.NodeSelector = podSpec.NodeSelector
+	template.Spec.Tolerations = podSpec.Tolerations
+	template.Spec.PriorityClassName = podSpec.PriorityClassName
+	template.Spec.RuntimeClassName = podSpec.RuntimeClassName
+	template.Spec.SchedulerName = podSpec.SchedulerName
+	template.Spec.ImagePullSecrets = podSpec.ImagePullSecrets
+}
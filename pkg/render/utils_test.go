@@ -1,7 +1,6 @@
-package controller
+package render
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -42,30 +41,44 @@ var _ = Describe("Model Artifacts", func() {
 		tests := map[string]struct {
 			expectedURIType        URIType
 			expectedModelMountPath string
+			expectedErr            bool
 		}{
 			"pvc://pvc-name/path/to/model": {
 				expectedURIType:        PVC,
-				expectedModelMountPath: modelStorageRoot + pathSep + "path/to/model",
+				expectedModelMountPath: ModelStorageRoot + pathSep + "path/to/model",
+			},
+			"pvc://pvc-name/path/to/model/": {
+				expectedURIType:        PVC,
+				expectedModelMountPath: ModelStorageRoot + pathSep + "path/to/model",
 			},
 			"oci://repo-with-tag::path/to/model": {
 				expectedURIType:        OCI,
-				expectedModelMountPath: "", // TODO
+				expectedModelMountPath: ModelStorageRoot + pathSep + "path/to/model",
 			},
 			"hf://repo-id/model-id": {
 				expectedURIType:        HF,
-				expectedModelMountPath: modelStorageRoot,
+				expectedModelMountPath: ModelStorageRoot,
 			},
 			"pvc://pvc-name": {
 				expectedURIType:        PVC,
 				expectedModelMountPath: "",
+				expectedErr:            true,
 			},
 			"oci://": {
 				expectedURIType:        OCI,
-				expectedModelMountPath: "", // TODO
+				expectedModelMountPath: ModelStorageRoot,
 			},
 			"hf://wrong": {
 				expectedURIType:        HF,
-				expectedModelMountPath: modelStorageRoot,
+				expectedModelMountPath: ModelStorageRoot,
+			},
+			"s3://bucket/path/to/model": {
+				expectedURIType:        S3,
+				expectedModelMountPath: ModelStorageRoot + "/path/to/model",
+			},
+			"gs://bucket/model/": {
+				expectedURIType:        GCS,
+				expectedModelMountPath: ModelStorageRoot + "/model",
 			},
 			"random://": {
 				expectedURIType:        UnknownURI,
@@ -109,8 +122,8 @@ var _ = Describe("Model Artifacts", func() {
 					},
 				})
 
-				// Expect error if uri type is unknown
-				if answer.expectedURIType == UnknownURI {
+				// Expect error if uri type is unknown, or the URI is invalid
+				if answer.expectedURIType == UnknownURI || answer.expectedErr {
 					Expect(err).To(HaveOccurred())
 				} else {
 					Expect(err).ToNot(HaveOccurred())
@@ -122,7 +135,6 @@ var _ = Describe("Model Artifacts", func() {
 	})
 
 	Context("Given a model artifact with a valid PVC URI", func() {
-		ctx := context.Background()
 		modelArtifact := msv1alpha1.ModelArtifacts{
 			URI: fmt.Sprintf("pvc://%s/%s", PVC_NAME, MODEL_PATH),
 		}
@@ -147,32 +159,32 @@ var _ = Describe("Model Artifacts", func() {
 			Expect(strings.Join(parts[1:], "/")).To(Equal(MODEL_PATH))
 		})
 		It("should produce a valid volumeMounts list", func() {
-			volumeMounts := getVolumeMountsForContainer(ctx, &modelService)
+			volumeMounts := getVolumeMountsForContainer(&modelService)
 			Expect(len(volumeMounts)).To(Equal(1))
 			firstVolumeMount := volumeMounts[0]
 
-			Expect(firstVolumeMount.Name).To(Equal(modelStorageVolumeName))
-			Expect(firstVolumeMount.MountPath).To(Equal(modelStorageRoot))
+			Expect(firstVolumeMount.Name).To(Equal(ModelStorageVolumeName))
+			Expect(firstVolumeMount.MountPath).To(Equal(ModelStorageRoot + pathSep + MODEL_PATH))
+			Expect(firstVolumeMount.SubPath).To(Equal(MODEL_PATH))
 			Expect(firstVolumeMount.ReadOnly).To(BeTrue())
 		})
 		It("should produce a valid volumes list", func() {
-			volumes := getVolumeForPDDeployment(ctx, &modelService)
+			volumes := getVolumeForPDDeployment(&modelService)
 			Expect(len(volumes)).To(Equal(1))
 			firstVolume := volumes[0]
-			Expect(firstVolume.Name).To(Equal(modelStorageVolumeName))
+			Expect(firstVolume.Name).To(Equal(ModelStorageVolumeName))
 			Expect(firstVolume.PersistentVolumeClaim.ClaimName).To(Equal(PVC_NAME))
 			Expect(firstVolume.PersistentVolumeClaim.ReadOnly).To(BeTrue())
 		})
 
 		It("should produce a valid env list", func() {
-			envs := getEnvsForContainer(ctx, &modelService)
+			envs := getEnvsForContainer(&modelService)
 			Expect(len(envs)).To(Equal(0))
 		})
 	})
 
 	Context("Given a model artifact with a valid HF URI", func() {
 
-		ctx := context.Background()
 		authSecretName := "auth-secret-key"
 		sizeLimit := "5Gi"
 		sizeLimitQuan := resource.MustParse(sizeLimit)
@@ -203,25 +215,25 @@ var _ = Describe("Model Artifacts", func() {
 		})
 
 		It("should produce a valid volumeMounts list", func() {
-			volumeMounts := getVolumeMountsForContainer(ctx, &modelService)
+			volumeMounts := getVolumeMountsForContainer(&modelService)
 			Expect(len(volumeMounts)).To(Equal(1))
 			firstVolumeMount := volumeMounts[0]
 
-			Expect(firstVolumeMount.Name).To(Equal(modelStorageVolumeName))
-			Expect(firstVolumeMount.MountPath).To(Equal(modelStorageRoot))
+			Expect(firstVolumeMount.Name).To(Equal(ModelStorageVolumeName))
+			Expect(firstVolumeMount.MountPath).To(Equal(ModelStorageRoot))
 			Expect(firstVolumeMount.ReadOnly).To(BeFalse())
 		})
 
 		It("should produce a valid volumes list", func() {
-			volumes := getVolumeForPDDeployment(ctx, &modelService)
+			volumes := getVolumeForPDDeployment(&modelService)
 			Expect(len(volumes)).To(Equal(1))
 			firstVolume := volumes[0]
-			Expect(firstVolume.Name).To(Equal(modelStorageVolumeName))
+			Expect(firstVolume.Name).To(Equal(ModelStorageVolumeName))
 			Expect(firstVolume.EmptyDir.SizeLimit.String()).To(Equal(sizeLimit))
 		})
 
 		It("should produce a valid env list", func() {
-			envs := getEnvsForContainer(ctx, &modelService)
+			envs := getEnvsForContainer(&modelService)
 			Expect(len(envs)).To(Equal(2))
 			hfTokenEnvVar := envs[0]
 
@@ -231,7 +243,7 @@ var _ = Describe("Model Artifacts", func() {
 
 			hfHomeEnvVar := envs[1]
 			Expect(hfHomeEnvVar.Name).To(Equal(ENV_HF_HOME))
-			Expect(hfHomeEnvVar.Value).To(Equal(modelStorageRoot))
+			Expect(hfHomeEnvVar.Value).To(Equal(ModelStorageRoot))
 		})
 	})
 })
@@ -0,0 +1,46 @@
+package render
+
+import (
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// writableCacheVolumes returns the writable cache volume of the prefill and decode pods,
+// if modelArtifacts.writableCache is set
+func writableCacheVolumes(msvc *msv1alpha1.ModelService) []corev1.Volume {
+	cache := msvc.Spec.ModelArtifacts.WritableCache
+	if cache == nil {
+		return nil
+	}
+
+	volume := corev1.Volume{Name: WritableCacheVolumeName}
+	if cache.ClaimName != "" {
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: cache.ClaimName}
+	} else {
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{SizeLimit: cache.Size}
+	}
+	return []corev1.Volume{volume}
+}
+
+// writableCacheMounts returns the mount of the writable cache volume
+func writableCacheMounts(msvc *msv1alpha1.ModelService) []corev1.VolumeMount {
+	if msvc.Spec.ModelArtifacts.WritableCache == nil {
+		return nil
+	}
+	return []corev1.VolumeMount{{Name: WritableCacheVolumeName, MountPath: WritableCacheRoot}}
+}
+
+// writableCacheEnvs points the caches written by the model server to the writable cache volume
+func writableCacheEnvs(msvc *msv1alpha1.ModelService) []corev1.EnvVar {
+	if msvc.Spec.ModelArtifacts.WritableCache == nil {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: ENV_XDG_CACHE_HOME, Value: WritableCacheRoot},
+		{Name: ENV_VLLM_CACHE_ROOT, Value: WritableCacheRoot + "/vllm"},
+		{Name: ENV_TORCHINDUCTOR_CACHE_DIR, Value: WritableCacheRoot + "/torchinductor"},
+		{Name: ENV_TRITON_CACHE_DIR, Value: WritableCacheRoot + "/triton"},
+		// flashinfer writes its JIT cache to $FLASHINFER_WORKSPACE_BASE/.cache/flashinfer
+		{Name: ENV_FLASHINFER_WORKSPACE_BASE, Value: WritableCacheRoot},
+	}
+}
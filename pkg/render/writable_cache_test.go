@@ -0,0 +1,55 @@
+package render
+
+import (
+	"testing"
+
+	msv1alpha1 "github.com/llm-d/llm-d-model-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestWritableCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		cache  *msv1alpha1.WritableCache
+		volume corev1.VolumeSource
+	}{
+		{
+			name:   "emptyDir",
+			cache:  &msv1alpha1.WritableCache{Size: ptr.To(resource.MustParse("5Gi"))},
+			volume: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: ptr.To(resource.MustParse("5Gi"))}},
+		},
+		{
+			name:   "claim",
+			cache:  &msv1alpha1.WritableCache{ClaimName: "compile-cache"},
+			volume: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "compile-cache"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msvc := minimalMSVC()
+			msvc.Spec.ModelArtifacts = msv1alpha1.ModelArtifacts{URI: "pvc://models/granite", WritableCache: tt.cache}
+
+			volumes := getVolumeForPDDeployment(msvc)
+			require.Len(t, volumes, 2)
+			assert.Equal(t, corev1.Volume{Name: WritableCacheVolumeName, VolumeSource: tt.volume}, volumes[1])
+
+			mounts := getVolumeMountsForContainer(msvc)
+			require.Len(t, mounts, 2)
+			assert.True(t, mounts[0].ReadOnly)
+			assert.Equal(t, corev1.VolumeMount{Name: WritableCacheVolumeName, MountPath: WritableCacheRoot}, mounts[1])
+
+			assert.Contains(t, getEnvsForContainer(msvc), corev1.EnvVar{Name: ENV_VLLM_CACHE_ROOT, Value: WritableCacheRoot + "/vllm"})
+		})
+	}
+
+	msvc := minimalMSVC()
+	msvc.Spec.ModelArtifacts.URI = "pvc://models/granite"
+	assert.Len(t, getVolumeForPDDeployment(msvc), 1)
+	assert.Len(t, getVolumeMountsForContainer(msvc), 1)
+	assert.Empty(t, getEnvsForContainer(msvc))
+}
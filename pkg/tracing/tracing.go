@@ -0,0 +1,31 @@
+// Package tracing holds the span helpers shared by the render library and the ModelService controller.
+// It only depends on the OpenTelemetry API; the controller configures the tracer provider
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes set on the spans of the reconcile pipeline
+const (
+	KindKey      = attribute.Key("k8s.object.kind")
+	NamespaceKey = attribute.Key("k8s.object.namespace")
+	NameKey      = attribute.Key("k8s.object.name")
+	OperationKey = attribute.Key("k8s.operation")
+)
+
+// ObjectAttributes returns the attributes identifying a Kubernetes object
+func ObjectAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{KindKey.String(kind), NamespaceKey.String(namespace), NameKey.String(name)}
+}
+
+// EndSpan ends span, recording err as its status if it is not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}